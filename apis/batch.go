@@ -0,0 +1,297 @@
+package apis
+
+import (
+	"blacklist/models"
+	"blacklist/pkg/clients"
+	"blacklist/tools/protos"
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"sync"
+)
+
+var (
+	invalidRequest   = "record_id, client_id and product_id are required"
+	reservedWildcard = "ids can not be %s, use scope to cover every client or product"
+	listMismatch     = "request belongs to list %q but its batch to list %q"
+)
+
+type batchStream interface {
+	Context() context.Context
+	Recv() (*blacklist.BlacklistBatchRequest, error)
+	Send(*blacklist.BlacklistBatchItemResult) error
+}
+
+type batchItem struct {
+	id string
+	// keys are fetched to resolve the item, just id unless it is a lookup or a check
+	keys    []string
+	list    *models.List
+	request *blacklist.BlacklistRecordOperationRequest
+	result  *blacklist.BlacklistBatchItemResult
+	done    chan struct{}
+}
+
+func (receiver *batchItem) succeed(record *models.Record) {
+	receiver.result = &blacklist.BlacklistBatchItemResult{Id: receiver.id, Status: blacklist.BatchItemStatus_OK}
+	if record != nil {
+		receiver.result.Record = record.ToDto()
+	}
+}
+
+func (receiver *batchItem) notFound() {
+	receiver.setError(blacklist.BatchItemStatus_NOT_FOUND, notFoundError(receiver.id))
+}
+
+func (receiver *batchItem) fail(err error) {
+	receiver.setError(blacklist.BatchItemStatus_FAILED, toStatus(err))
+}
+
+func (receiver *batchItem) setError(itemStatus blacklist.BatchItemStatus, err error) {
+	errorStatus := status.Convert(err)
+	receiver.result = &blacklist.BlacklistBatchItemResult{
+		Id:     receiver.id,
+		Status: itemStatus,
+		Error:  errorStatus.Message(),
+		Code:   int32(errorStatus.Code()),
+	}
+}
+
+// validateRequest checks every id is given, the client and product ids are not
+// needed when the scope covers all of them.
+func validateRequest(request *blacklist.BlacklistRecordOperationRequest) error {
+	recordId, clientId, productId := models.ScopedIds(request)
+	if recordId == "" || clientId == "" || productId == "" {
+		return status.Error(codes.InvalidArgument, invalidRequest)
+	}
+	if request.RecordId == models.Wildcard || request.ClientId == models.Wildcard || request.ProductId == models.Wildcard {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf(reservedWildcard, models.Wildcard))
+	}
+	return nil
+}
+
+// newBatchItems derives the id of every request of a batch, requests which can
+// not be processed already get their INVALID result. The keys of an item are
+// the ones returned by keys, just its id when keys is nil. Requests without a
+// list are set to the one of the batch.
+func newBatchItems(requests []*blacklist.BlacklistRecordOperationRequest, list *models.List, keys func(request *blacklist.BlacklistRecordOperationRequest) []string) []*batchItem {
+	items := make([]*batchItem, 0, len(requests))
+	for _, request := range requests {
+		mismatch := request.List != "" && request.List != list.Name()
+		if !mismatch {
+			request.List = list.Name()
+		}
+		item := &batchItem{id: getIdFromRequest(request), list: list, request: request}
+		item.keys = []string{item.id}
+		if keys != nil {
+			item.keys = keys(request)
+		}
+		if err := validateRequest(request); err != nil {
+			item.setError(blacklist.BatchItemStatus_INVALID, err)
+		} else if mismatch {
+			item.setError(blacklist.BatchItemStatus_INVALID, status.Error(codes.InvalidArgument, fmt.Sprintf(listMismatch, request.List, list.Name())))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// unprocessedIds tells which ids a store could not process when it returns a
+// partial result, the rest of the batch went through.
+func unprocessedIds(err error) (map[string]bool, bool) {
+	var unprocessedErr *clients.UnprocessedError
+	if !errors.As(err, &unprocessedErr) {
+		return nil, false
+	}
+	ids := make(map[string]bool, len(unprocessedErr.Ids))
+	for _, id := range unprocessedErr.Ids {
+		ids[id] = true
+	}
+	return ids, true
+}
+
+func pendingItems(items []*batchItem) []*batchItem {
+	pending := make([]*batchItem, 0, len(items))
+	for _, item := range items {
+		if item.result == nil {
+			pending = append(pending, item)
+		}
+	}
+	return pending
+}
+
+// uniqueKeys returns the keys of the given items without repetitions, as the
+// stores reject batches containing the same key twice.
+func uniqueKeys(items []*batchItem) []*string {
+	seen := make(map[string]bool, len(items))
+	keys := make([]*string, 0, len(items))
+	for _, item := range items {
+		for _, key := range item.keys {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			key := key
+			keys = append(keys, &key)
+		}
+	}
+	return keys
+}
+
+func anyUnprocessed(keys []string, unprocessed map[string]bool) bool {
+	for _, key := range keys {
+		if unprocessed[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// chunkItems splits items in chunks holding at most clients.MaxBatchSize
+// distinct keys, items sharing an id always land in the same chunk.
+func chunkItems(items []*batchItem) [][]*batchItem {
+	chunkById := make(map[string]int, len(items))
+	chunks := make([][]*batchItem, 0, len(items)/clients.MaxBatchSize+1)
+	var keysInChunk map[string]bool
+	for _, item := range items {
+		if index, ok := chunkById[item.id]; ok {
+			chunks[index] = append(chunks[index], item)
+			continue
+		}
+		newKeys := 0
+		for _, key := range item.keys {
+			if !keysInChunk[key] {
+				newKeys++
+			}
+		}
+		if len(chunks) == 0 || len(keysInChunk)+newKeys > clients.MaxBatchSize {
+			chunks = append(chunks, make([]*batchItem, 0, clients.MaxBatchSize))
+			keysInChunk = make(map[string]bool, clients.MaxBatchSize)
+		}
+		for _, key := range item.keys {
+			keysInChunk[key] = true
+		}
+		chunkById[item.id] = len(chunks) - 1
+		chunks[len(chunks)-1] = append(chunks[len(chunks)-1], item)
+	}
+	return chunks
+}
+
+// processChunks runs process over the pending items, chunk by chunk with at
+// most Concurrency chunks in flight. It returns right away, the done channel of
+// every item is closed once its result is set.
+func (receiver *BlacklistServer) processChunks(ctx context.Context, store clients.RecordStore, items []*batchItem, process func(ctx context.Context, store clients.RecordStore, items []*batchItem) error) {
+	finished := make(chan struct{})
+	close(finished)
+	for _, item := range items {
+		if item.result != nil {
+			item.done = finished
+		}
+	}
+	concurrency := receiver.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	semaphore := make(chan struct{}, concurrency)
+	for _, chunk := range chunkItems(pendingItems(items)) {
+		done := make(chan struct{})
+		for _, item := range chunk {
+			item.done = done
+		}
+		go func(chunk []*batchItem, done chan struct{}) {
+			defer close(done)
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				failItems(chunk, ctx.Err())
+				return
+			}
+			err := process(ctx, store, chunk)
+			if err != nil {
+				failItems(chunk, err)
+			}
+		}(chunk, done)
+	}
+}
+
+// forEachParallel runs operation for every index below count at the same time
+// and waits for all of them, it is only used within a chunk.
+func forEachParallel(count int, operation func(index int)) {
+	var wg sync.WaitGroup
+	wg.Add(count)
+	for index := 0; index < count; index++ {
+		go func(index int) {
+			defer wg.Done()
+			operation(index)
+		}(index)
+	}
+	wg.Wait()
+}
+
+func failItems(items []*batchItem, err error) {
+	for _, item := range items {
+		item.fail(err)
+	}
+}
+
+type batchReceiver interface {
+	Context() context.Context
+	Recv() (*blacklist.BlacklistBatchRequest, error)
+}
+
+// receiveBatches runs process for every batch received on the stream, split
+// in chunks the stores accept, and calls send with every item in the order
+// they were requested. A failing chunk only marks its own items as FAILED,
+// the stream keeps going unless the request itself was cancelled. Lookups
+// and checks fetch every key of an item, see newBatchItems.
+func (receiver *BlacklistServer) receiveBatches(stream batchReceiver, keys func(request *blacklist.BlacklistRecordOperationRequest) []string, process func(ctx context.Context, store clients.RecordStore, items []*batchItem) error, send func(item *batchItem) error) error {
+	ctx := stream.Context()
+	store, err := receiver.store(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return toStatus(err)
+		}
+		list, err := receiver.getList(ctx, store, in.List)
+		if err != nil {
+			return err
+		}
+		// lists may lower the server limit but never raise it
+		batchSize := receiver.BatchSize
+		if list.MaxBatchSize() > 0 && list.MaxBatchSize() < batchSize {
+			batchSize = list.MaxBatchSize()
+		}
+		if len(in.Requests) > batchSize {
+			return maxLengthExceededError(batchSize, len(in.Requests))
+		}
+		items := newBatchItems(in.Requests, list, keys)
+		receiver.processChunks(ctx, store, items, process)
+		for _, item := range items {
+			select {
+			case <-item.done:
+			case <-ctx.Done():
+				return toStatus(ctx.Err())
+			}
+			err = send(item)
+			if err != nil {
+				return toStatus(err)
+			}
+		}
+	}
+}
+
+func (receiver *BlacklistServer) processBatches(stream batchStream, keys func(request *blacklist.BlacklistRecordOperationRequest) []string, process func(ctx context.Context, store clients.RecordStore, items []*batchItem) error) error {
+	return receiver.receiveBatches(stream, keys, process, func(item *batchItem) error {
+		return stream.Send(item.result)
+	})
+}
@@ -0,0 +1,213 @@
+package apis
+
+import (
+	"blacklist/models"
+	"blacklist/pkg/clients"
+	"blacklist/tools/protos"
+	"context"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingStore fails the test when the server sends the store a batch with
+// repeated keys or more than clients.MaxBatchSize of them.
+type recordingStore struct {
+	clients.RecordStore
+	t       *testing.T
+	mu      sync.Mutex
+	batches int
+}
+
+func (receiver *recordingStore) check(ids []*string) {
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	receiver.batches++
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[*id] {
+			receiver.t.Errorf("batch sent to the store repeats key %q", *id)
+		}
+		seen[*id] = true
+	}
+	if len(seen) > clients.MaxBatchSize {
+		receiver.t.Errorf("batch sent to the store holds %d keys, the most is %d", len(seen), clients.MaxBatchSize)
+	}
+}
+
+func (receiver *recordingStore) GetRecordBatchByIds(ctx context.Context, ids []*string) ([]*models.Record, error) {
+	receiver.check(ids)
+	return receiver.RecordStore.GetRecordBatchByIds(ctx, ids)
+}
+
+// blockingStore never answers a batch before its context is cancelled.
+type blockingStore struct {
+	clients.RecordStore
+}
+
+func (receiver *blockingStore) GetRecordBatchByIds(ctx context.Context, ids []*string) ([]*models.Record, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// batchRequests returns more requests than fit in a chunk, repeating ids and
+// mixing exact requests with ones scoped to all the products.
+func batchRequests() []*blacklist.BlacklistRecordOperationRequest {
+	requests := make([]*blacklist.BlacklistRecordOperationRequest, 0, 60)
+	for i := 0; i < 60; i++ {
+		request := &blacklist.BlacklistRecordOperationRequest{
+			RecordId:  fmt.Sprintf("r%d", i%20),
+			ClientId:  fmt.Sprintf("c%d", i%3),
+			ProductId: fmt.Sprintf("p%d", i%2),
+		}
+		if i%7 == 0 {
+			request.Scope = blacklist.BlockScope_ALL_PRODUCTS
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// blockedByBatchFixture tells whether a request of batchRequests is covered by
+// the blocks saved by newBatchClient.
+func blockedByBatchFixture(request *blacklist.BlacklistRecordOperationRequest) bool {
+	var index int
+	fmt.Sscanf(request.RecordId, "r%d", &index)
+	return index < 5 && request.ClientId == "c0"
+}
+
+// newBatchClient serves a memory store holding blocks of the first five
+// records for every product of client c0.
+func newBatchClient(t *testing.T) (blacklist.BlacklistClient, *recordingStore) {
+	store := &recordingStore{RecordStore: clients.NewMemoryClient(), t: t}
+	client := newTestClient(t, &BlacklistServer{Store: store, BatchSize: 100, Concurrency: 4})
+	for i := 0; i < 5; i++ {
+		_, err := client.SaveBlacklistRecord(context.Background(), &blacklist.BlacklistRecordOperationRequest{
+			RecordId: fmt.Sprintf("r%d", i), ClientId: "c0", Scope: blacklist.BlockScope_ALL_PRODUCTS,
+		})
+		if err != nil {
+			t.Fatalf("saving the blocks: %v", err)
+		}
+	}
+	return client, store
+}
+
+func TestChunkItemsBoundsKeys(t *testing.T) {
+	items := newBatchItems(batchRequests(), models.DefaultList, getCheckKeys)
+	chunks := chunkItems(items)
+	chunkById := make(map[string]int)
+	count := 0
+	for index, chunk := range chunks {
+		keys := make(map[string]bool)
+		for _, item := range chunk {
+			count++
+			for _, key := range item.keys {
+				keys[key] = true
+			}
+			if previous, ok := chunkById[item.id]; ok && previous != index {
+				t.Errorf("items with id %s are in chunks %d and %d", item.id, previous, index)
+			}
+			chunkById[item.id] = index
+		}
+		if len(keys) > clients.MaxBatchSize {
+			t.Errorf("chunk %d holds %d keys, the most is %d", index, len(keys), clients.MaxBatchSize)
+		}
+	}
+	if count != len(items) {
+		t.Errorf("chunks hold %d items, want %d", count, len(items))
+	}
+	if len(chunks) < 2 {
+		t.Errorf("%d items fit in %d chunk", len(items), len(chunks))
+	}
+}
+
+func TestIsBlacklistedBatchAnswersInOrder(t *testing.T) {
+	client, store := newBatchClient(t)
+	requests := batchRequests()
+	stream, err := client.IsBlacklistedBatch(context.Background())
+	if err != nil {
+		t.Fatalf("opening the batch: %v", err)
+	}
+	err = stream.Send(&blacklist.BlacklistBatchRequest{Requests: requests})
+	if err != nil {
+		t.Fatalf("sending the batch: %v", err)
+	}
+	err = stream.CloseSend()
+	if err != nil {
+		t.Fatalf("closing the batch: %v", err)
+	}
+	var results []*blacklist.BlacklistCheckResult
+	for {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("receiving the batch: %v", err)
+		}
+		results = append(results, result)
+	}
+	if len(results) != len(requests) {
+		t.Fatalf("got %d results for %d requests", len(results), len(requests))
+	}
+	for index, request := range requests {
+		result := results[index]
+		if result.RecordId != request.RecordId || result.ClientId != request.ClientId || result.ProductId != request.ProductId {
+			t.Errorf("result %d answers %s:%s:%s, want %s:%s:%s", index, result.RecordId, result.ClientId, result.ProductId, request.RecordId, request.ClientId, request.ProductId)
+		}
+		if result.Status != blacklist.BatchItemStatus_OK || result.Code != 0 {
+			t.Errorf("result %d = %v, want OK", index, result)
+		}
+		if want := blockedByBatchFixture(request); result.Blacklisted != want {
+			t.Errorf("result %d blacklisted = %t, want %t", index, result.Blacklisted, want)
+		}
+	}
+	if store.batches < 2 {
+		t.Errorf("the batch reached the store in %d chunk", store.batches)
+	}
+}
+
+func TestGetBlacklistRecordBatchAnswersInOrder(t *testing.T) {
+	client, _ := newBatchClient(t)
+	requests := batchRequests()
+	stream, err := client.GetBlacklistRecordBatch(context.Background())
+	results := sendBatch(t, stream, err, &blacklist.BlacklistBatchRequest{Requests: requests})
+	if len(results) != len(requests) {
+		t.Fatalf("got %d results for %d requests", len(results), len(requests))
+	}
+	for index, request := range requests {
+		result := results[index]
+		if want := getIdFromRequest(request); result.Id != want {
+			t.Errorf("result %d has id %s, want %s", index, result.Id, want)
+		}
+		want := blacklist.BatchItemStatus_NOT_FOUND
+		if blockedByBatchFixture(request) {
+			want = blacklist.BatchItemStatus_OK
+		}
+		if result.Status != want {
+			t.Errorf("result %d = %v, want %s", index, result, want)
+		}
+	}
+}
+
+func TestProcessChunksFailsItemsOnCancel(t *testing.T) {
+	server := &BlacklistServer{Concurrency: 1}
+	items := newBatchItems(batchRequests(), models.DefaultList, getCheckKeys)
+	ctx, cancel := context.WithCancel(context.Background())
+	server.processChunks(ctx, &blockingStore{clients.NewMemoryClient()}, items, server.lookupItems)
+	cancel()
+	timeout := time.After(5 * time.Second)
+	for index, item := range items {
+		select {
+		case <-item.done:
+		case <-timeout:
+			t.Fatalf("item %d is still pending after the context was cancelled", index)
+		}
+		if item.result.Status != blacklist.BatchItemStatus_FAILED || codes.Code(item.result.Code) != codes.Canceled {
+			t.Errorf("item %d = %v, want FAILED with code %s", index, item.result, codes.Canceled)
+		}
+	}
+}
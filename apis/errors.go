@@ -0,0 +1,98 @@
+package apis
+
+import (
+	"blacklist/pkg/clients"
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"time"
+)
+
+const retryDelay = time.Second
+
+func notFoundError(id string) error {
+	result := status.New(codes.NotFound, fmt.Sprintf(notFound, id))
+	detailed, err := result.WithDetails(&errdetails.ResourceInfo{ResourceType: "BlacklistRecord", ResourceName: id})
+	if err != nil {
+		return result.Err()
+	}
+	return detailed.Err()
+}
+
+func listNotFoundError(name string) error {
+	result := status.New(codes.NotFound, fmt.Sprintf(listNotFound, name))
+	detailed, err := result.WithDetails(&errdetails.ResourceInfo{ResourceType: "BlacklistList", ResourceName: name})
+	if err != nil {
+		return result.Err()
+	}
+	return detailed.Err()
+}
+
+func maxLengthExceededError(maxSize, size int) error {
+	message := fmt.Sprintf(maxLengthExceeded, maxSize, size)
+	result := status.New(codes.InvalidArgument, message)
+	detailed, err := result.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "requests", Description: message}},
+	})
+	if err != nil {
+		return result.Err()
+	}
+	return detailed.Err()
+}
+
+func retryableError(code codes.Code, cause error) error {
+	result := status.New(code, cause.Error())
+	detailed, err := result.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)})
+	if err != nil {
+		return result.Err()
+	}
+	return detailed.Err()
+}
+
+// toStatus maps the errors returned by the stores into gRPC status errors, so
+// callers can branch on the code instead of parsing messages. Errors which are
+// already a status, like the ones returned by the streams, are kept as they are.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if errors.Is(err, clients.ErrMaxBatchExceeded) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, clients.ErrAlreadyExists) || errors.Is(err, clients.ErrListExists) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	if errors.Is(err, clients.ErrVersionConflict) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, clients.ErrNotFound) || errors.Is(err, clients.ErrListNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	var unprocessedErr *clients.UnprocessedError
+	if errors.As(err, &unprocessedErr) {
+		return retryableError(codes.ResourceExhausted, err)
+	}
+	if errors.Is(err, clients.ErrThrottled) {
+		return retryableError(codes.ResourceExhausted, err)
+	}
+	if errors.Is(err, clients.ErrUnavailable) {
+		return retryableError(codes.Unavailable, err)
+	}
+	if errors.Is(err, clients.ErrInvalidRequest) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,82 @@
+package apis
+
+import (
+	"blacklist/models"
+	"blacklist/pkg/clients"
+	"blacklist/tools/protos"
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var defaultListDeleted = "the default list can not be deleted"
+
+// getList returns the list named by a request, the default one when the name is
+// empty. Lists are read on every request, so all the servers sharing a store
+// see a list as soon as it is created or deleted.
+func (receiver *BlacklistServer) getList(ctx context.Context, store clients.RecordStore, name string) (*models.List, error) {
+	if name == "" {
+		return models.DefaultList, nil
+	}
+	list, err := store.GetList(ctx, name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if list == nil {
+		return nil, listNotFoundError(name)
+	}
+	return list, nil
+}
+
+func (receiver *BlacklistServer) CreateList(ctx context.Context, request *blacklist.CreateListRequest) (*blacklist.ListDto, error) {
+	store, err := receiver.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := models.FromCreateListRequest(request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	err = store.CreateList(ctx, list)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return list.ToDto(), nil
+}
+
+func (receiver *BlacklistServer) DescribeList(ctx context.Context, request *blacklist.ListRequest) (*blacklist.ListDto, error) {
+	store, err := receiver.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := receiver.getList(ctx, store, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return list.ToDto(), nil
+}
+
+// DeleteList deletes every record of the list, expired ones included, and then
+// the list itself, so a failed deletion can be retried.
+func (receiver *BlacklistServer) DeleteList(ctx context.Context, request *blacklist.ListRequest) (*blacklist.Empty, error) {
+	if request.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, defaultListDeleted)
+	}
+	store, err := receiver.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := receiver.getList(ctx, store, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	err = store.DeleteRecordsByQueries(ctx, []*models.Query{list.Query()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	err = store.DeleteList(ctx, list.Name())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &blacklist.Empty{}, nil
+}
@@ -0,0 +1,72 @@
+package apis
+
+import (
+	"blacklist/pkg/clients"
+	"blacklist/tools/protos"
+	"context"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func assertExpiresAround(t *testing.T, record *blacklist.BlacklistRecordDto, want time.Time) {
+	t.Helper()
+	if record.ExpiresAt == nil {
+		t.Fatalf("record %v does not expire, want around %s", record, want)
+	}
+	if got := record.ExpiresAt.AsTime(); got.Before(want.Add(-time.Minute)) || got.After(want.Add(time.Minute)) {
+		t.Errorf("record expires at %s, want around %s", got, want)
+	}
+}
+
+func TestListDefaultTtlKeepsStoredExpiration(t *testing.T) {
+	client := newTestClient(t, &BlacklistServer{Store: clients.NewMemoryClient(), BatchSize: 100})
+	ctx := context.Background()
+	_, err := client.CreateList(ctx, &blacklist.CreateListRequest{Name: "fraud", DefaultTtl: durationpb.New(time.Hour)})
+	if err != nil {
+		t.Fatalf("creating the list: %v", err)
+	}
+	request := func(recordId string) *blacklist.BlacklistRecordOperationRequest {
+		return &blacklist.BlacklistRecordOperationRequest{List: "fraud", RecordId: recordId, ClientId: "c", ProductId: "p"}
+	}
+	inAMonth := time.Now().Add(30 * 24 * time.Hour)
+
+	saved, err := client.SaveBlacklistRecord(ctx, request("new"))
+	if err != nil {
+		t.Fatalf("saving: %v", err)
+	}
+	assertExpiresAround(t, saved, time.Now().Add(time.Hour))
+
+	for _, recordId := range []string{"unary", "batch"} {
+		block := request(recordId)
+		block.ExpiresAt = timestamppb.New(inAMonth)
+		_, err = client.SaveBlacklistRecord(ctx, block)
+		if err != nil {
+			t.Fatalf("saving: %v", err)
+		}
+	}
+	upsert := request("unary")
+	upsert.Note = "only the note changes"
+	saved, err = client.SaveBlacklistRecord(ctx, upsert)
+	if err != nil {
+		t.Fatalf("upserting: %v", err)
+	}
+	assertExpiresAround(t, saved, inAMonth)
+	upsert = request("batch")
+	upsert.Note = "only the note changes"
+	stream, err := client.SaveBlacklistRecordBatch(ctx)
+	results := sendBatch(t, stream, err, &blacklist.BlacklistBatchRequest{List: "fraud", Requests: []*blacklist.BlacklistRecordOperationRequest{upsert}})
+	if len(results) != 1 || results[0].Status != blacklist.BatchItemStatus_OK {
+		t.Fatalf("batch upsert = %v, want one OK result", results)
+	}
+	assertExpiresAround(t, results[0].Record, inAMonth)
+
+	overwrite := request("unary")
+	overwrite.Mode = blacklist.SaveMode_OVERWRITE
+	saved, err = client.SaveBlacklistRecord(ctx, overwrite)
+	if err != nil {
+		t.Fatalf("overwriting: %v", err)
+	}
+	assertExpiresAround(t, saved, time.Now().Add(time.Hour))
+}
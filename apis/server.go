@@ -6,81 +6,147 @@ import (
 	"blacklist/tools/protos"
 	"context"
 	"errors"
-	"fmt"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
-	"io"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"sync"
 )
 
 var (
 	notFound          = "given record %s does not exist"
+	listNotFound      = "given list %s does not exist"
 	maxLengthExceeded = "maximum batch size is %d and given batch has %d records"
-	idFormat          = "%s:%s:%s"
 )
 
+// getIdFromRequest returns the id of the block a request addresses, taken from
+// the record a save builds so both always agree.
 func getIdFromRequest(request *blacklist.BlacklistRecordOperationRequest) string {
-	return fmt.Sprintf(idFormat, request.RecordId, request.ClientId, request.ClientId)
+	return models.FromOperationRequest(request).Id()
+}
+
+// getLookupKeys returns the keys of every block applying to the request, from
+// the most to the least specific one. Requests for a scoped block only look it up.
+func getLookupKeys(request *blacklist.BlacklistRecordOperationRequest) []string {
+	if request.Scope != blacklist.BlockScope_EXACT {
+		return []string{getIdFromRequest(request)}
+	}
+	keys := models.CandidateKeys(request.RecordId, request.ClientId, request.ProductId)
+	for index, key := range keys {
+		keys[index] = models.ListKey(request.List, key)
+	}
+	return keys
+}
+
+func getAllowIdFromRequest(request *blacklist.BlacklistRecordOperationRequest) string {
+	return models.FromOperationRequest(request).AsAllowlistEntry().Id()
+}
+
+// getCheckKeys returns the keys of every block and allowlist entry applying to
+// the request, from the most to the least specific one. Allowlist entries come
+// right before the block of the same scope so they win over it.
+func getCheckKeys(request *blacklist.BlacklistRecordOperationRequest) []string {
+	lookupKeys := getLookupKeys(request)
+	keys := make([]string, 0, 2*len(lookupKeys))
+	for _, key := range lookupKeys {
+		keys = append(keys, models.AllowKey(key), key)
+	}
+	return keys
 }
 
 type BlacklistServer struct {
 	blacklist.UnimplementedBlacklistServer
-	mu        sync.Mutex
-	BatchSize int
-	Table     string
+	mu sync.Mutex
+	// BatchSize is the most requests accepted in a single batch message, they
+	// are split in chunks of clients.MaxBatchSize and Concurrency of them are
+	// sent to the store at a time
+	BatchSize   int
+	Concurrency int
+	Store       clients.RecordStore
+	// Tenants isolates the records and lists of every tenant when set, requests
+	// have to go through its interceptors to be authenticated as one
+	Tenants *Tenants
 }
 
-func (receiver *BlacklistServer) GetBlacklistRecord(_ context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.BlacklistRecordDto, error) {
-	client, err := clients.NewClient(receiver.Table)
+func (receiver *BlacklistServer) GetBlacklistRecord(ctx context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.BlacklistRecordDto, error) {
+	store, err := receiver.store(ctx)
 	if err != nil {
 		return nil, err
 	}
-	id := getIdFromRequest(request)
-	result, err := client.GetRecordById(&id)
+	_, err = receiver.getList(ctx, store, request.List)
 	if err != nil {
 		return nil, err
 	}
+	result, err := receiver.lookup(ctx, store, getLookupKeys(request))
+	if err != nil {
+		return nil, toStatus(err)
+	}
 	if result == nil {
-		return nil, errors.New(fmt.Sprintf(notFound, id))
+		return nil, notFoundError(getIdFromRequest(request))
 	}
 	return result.ToDto(), nil
 }
 
-func (receiver *BlacklistServer) GetBlacklistRecordBatch(stream blacklist.Blacklist_GetBlacklistRecordBatchServer) error {
-	client, err := clients.NewClient(receiver.Table)
+// lookup returns the record stored under the first of keys, fetching all of
+// them in one batch get.
+func (receiver *BlacklistServer) lookup(ctx context.Context, store clients.RecordStore, keys []string) (*models.Record, error) {
+	ids := make([]*string, 0, len(keys))
+	for index := range keys {
+		ids = append(ids, &keys[index])
+	}
+	records, err := store.GetRecordBatchByIds(ctx, ids)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	for {
-		in, err := stream.Recv()
-		ids := make([]*string, 0, receiver.BatchSize)
-		if err == io.EOF {
-			return nil
-		}
-		if err != nil {
-			return err
-		}
-		if len(in.Requests) > receiver.BatchSize {
-			return errors.New(fmt.Sprintf(maxLengthExceeded, receiver.BatchSize, len(in.Requests)))
-		}
-		for _, request := range in.Requests {
-			id := getIdFromRequest(request)
-			ids = append(ids, &id)
-		}
-		records, err := client.GetRecordBatchByIds(ids)
-		if err != nil {
-			return err
+	return mostSpecific(keys, indexRecords(records)), nil
+}
+
+func indexRecords(records []*models.Record) map[string]*models.Record {
+	recordsById := make(map[string]*models.Record, len(records))
+	for _, record := range records {
+		recordsById[record.Id()] = record
+	}
+	return recordsById
+}
+
+func mostSpecific(keys []string, recordsById map[string]*models.Record) *models.Record {
+	for _, key := range keys {
+		if record, ok := recordsById[key]; ok {
+			return record
 		}
-		for _, record := range records {
-			err = stream.Send(record.ToDto())
-			if err != nil {
-				return err
-			}
+	}
+	return nil
+}
+
+func (receiver *BlacklistServer) GetBlacklistRecordBatch(stream blacklist.Blacklist_GetBlacklistRecordBatchServer) error {
+	return receiver.processBatches(stream, getLookupKeys, receiver.lookupItems)
+}
+
+// lookupItems resolves the items of a chunk built from their lookup keys.
+func (receiver *BlacklistServer) lookupItems(ctx context.Context, store clients.RecordStore, items []*batchItem) error {
+	records, err := store.GetRecordBatchByIds(ctx, uniqueKeys(items))
+	unprocessed, partial := unprocessedIds(err)
+	if err != nil && !partial {
+		return err
+	}
+	recordsById := indexRecords(records)
+	for _, item := range items {
+		if anyUnprocessed(item.keys, unprocessed) {
+			item.fail(err)
+		} else if record := mostSpecific(item.keys, recordsById); record != nil {
+			item.succeed(record)
+		} else {
+			item.notFound()
 		}
 	}
+	return nil
 }
 
 func (receiver *BlacklistServer) GetBlacklistRecordsQuery(request *blacklist.BlacklistRecordQueriesRequest, stream blacklist.Blacklist_GetBlacklistRecordsQueryServer) error {
-	client, err := clients.NewClient(receiver.Table)
+	ctx := stream.Context()
+	store, err := receiver.store(ctx)
+	if err != nil {
+		return err
+	}
+	list, err := receiver.getList(ctx, store, request.List)
 	if err != nil {
 		return err
 	}
@@ -92,114 +158,270 @@ func (receiver *BlacklistServer) GetBlacklistRecordsQuery(request *blacklist.Bla
 	for _, betweenQuery := range request.BetweenQueries {
 		betweenQueries = append(betweenQueries, models.FromQueryBetweenRequest(betweenQuery))
 	}
+	// allowlist entries are exceptions to blocks, not records of their own
+	queries = append(queries, list.Query(), models.BlocksQuery())
 	var result []*models.Record
-	var lastRecord map[string]*dynamodb.AttributeValue
-	for result, lastRecord, err = client.GetRecordsByQueries(queries, betweenQueries, nil); lastRecord != nil; result, lastRecord, err = client.GetRecordsByQueries(queries, betweenQueries, lastRecord) {
+	var lastKey *string
+	for result, lastKey, err = store.GetRecordsByQueries(ctx, queries, betweenQueries, nil); lastKey != nil; result, lastKey, err = store.GetRecordsByQueries(ctx, queries, betweenQueries, lastKey) {
 		if err != nil {
-			return err
+			return toStatus(err)
 		}
 		for _, record := range result {
 			err := stream.Send(record.ToDto())
 			if err != nil {
-				return err
+				return toStatus(err)
 			}
 		}
 	}
 	if err != nil {
-		return err
+		return toStatus(err)
 	}
 	for _, record := range result {
 		err := stream.Send(record.ToDto())
 		if err != nil {
-			return err
+			return toStatus(err)
 		}
 	}
 	return nil
 }
 
-func (receiver *BlacklistServer) SaveBlacklistRecord(_ context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.BlacklistRecordDto, error) {
-	client, err := clients.NewClient(receiver.Table)
+func (receiver *BlacklistServer) SaveBlacklistRecord(ctx context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.BlacklistRecordDto, error) {
+	return receiver.saveRecord(ctx, request, models.FromOperationRequest)
+}
+
+func (receiver *BlacklistServer) saveRecord(ctx context.Context, request *blacklist.BlacklistRecordOperationRequest, newRecord func(request *blacklist.BlacklistRecordOperationRequest) *models.Record) (*blacklist.BlacklistRecordDto, error) {
+	err := validateRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	store, err := receiver.store(ctx)
 	if err != nil {
 		return nil, err
 	}
-	record, err := client.SaveRecord(models.NewRecord(request.RecordId, request.ClientId, request.ProductId))
+	list, err := receiver.getList(ctx, store, request.List)
 	if err != nil {
 		return nil, err
 	}
+	record, err := store.SaveRecord(ctx, list.WithDefaults(newRecord(request)), models.SaveModeFromRequest(request), request.ExpectedVersion)
+	if err != nil {
+		return nil, toStatus(err)
+	}
 	return record.ToDto(), nil
 }
 
 func (receiver *BlacklistServer) SaveBlacklistRecordBatch(stream blacklist.Blacklist_SaveBlacklistRecordBatchServer) error {
-	client, err := clients.NewClient(receiver.Table)
-	if err != nil {
-		return err
-	}
-	for {
-		in, err := stream.Recv()
-		records := make([]*models.Record, 0, receiver.BatchSize)
-		if err == io.EOF {
-			return nil
-		}
-		if err != nil {
-			return err
+	return receiver.processBatches(stream, nil, receiver.saveItems)
+}
+
+// saveItems stores the last request for every id of the chunk. Every save is a
+// conditional write of its own, as it has to increment the stored version. All
+// the items of a chunk come from the same batch, so they share its list.
+func (receiver *BlacklistServer) saveItems(ctx context.Context, store clients.RecordStore, items []*batchItem) error {
+	requests := make([]*blacklist.BlacklistRecordOperationRequest, 0, len(items))
+	positions := make(map[string]int, len(items))
+	for _, item := range items {
+		// the last request for a repeated id is the one stored
+		if position, ok := positions[item.id]; ok {
+			requests[position] = item.request
+			continue
 		}
-		if len(in.Requests) > receiver.BatchSize {
-			return errors.New(fmt.Sprintf(maxLengthExceeded, receiver.BatchSize, len(in.Requests)))
+		positions[item.id] = len(requests)
+		requests = append(requests, item.request)
+	}
+	list := items[0].list
+	saved := make([]*models.Record, len(requests))
+	failed := make([]error, len(requests))
+	forEachParallel(len(requests), func(index int) {
+		request := requests[index]
+		record := list.WithDefaults(models.FromOperationRequest(request))
+		saved[index], failed[index] = store.SaveRecord(ctx, record, models.SaveModeFromRequest(request), request.ExpectedVersion)
+	})
+	for _, item := range items {
+		position := positions[item.id]
+		if failed[position] != nil {
+			item.fail(failed[position])
+		} else {
+			item.succeed(saved[position])
 		}
-		for _, request := range in.Requests {
-			record := models.NewRecord(request.RecordId, request.ClientId, request.ProductId)
-			records = append(records, record)
+	}
+	return nil
+}
+
+func (receiver *BlacklistServer) UpdateBlacklistRecord(ctx context.Context, request *blacklist.UpdateBlacklistRecordRequest) (*blacklist.BlacklistRecordDto, error) {
+	return receiver.updateRecord(ctx, request, getIdFromRequest)
+}
+
+func (receiver *BlacklistServer) updateRecord(ctx context.Context, request *blacklist.UpdateBlacklistRecordRequest, getId func(request *blacklist.BlacklistRecordOperationRequest) string) (*blacklist.BlacklistRecordDto, error) {
+	if request.Record == nil {
+		return nil, status.Error(codes.InvalidArgument, invalidRequest)
+	}
+	err := validateRequest(request.Record)
+	if err != nil {
+		return nil, err
+	}
+	update, err := models.FromUpdateRequest(request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	store, err := receiver.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, err = receiver.getList(ctx, store, request.Record.List)
+	if err != nil {
+		return nil, err
+	}
+	id := getId(request.Record)
+	record, err := store.UpdateRecord(ctx, &id, update, request.Record.ExpectedVersion)
+	if errors.Is(err, clients.ErrNotFound) {
+		return nil, notFoundError(id)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return record.ToDto(), nil
+}
+
+func (receiver *BlacklistServer) DeleteBlacklistRecord(ctx context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.Empty, error) {
+	return receiver.deleteRecord(ctx, request, getIdFromRequest)
+}
+
+func (receiver *BlacklistServer) deleteRecord(ctx context.Context, request *blacklist.BlacklistRecordOperationRequest, getId func(request *blacklist.BlacklistRecordOperationRequest) string) (*blacklist.Empty, error) {
+	store, err := receiver.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, err = receiver.getList(ctx, store, request.List)
+	if err != nil {
+		return nil, err
+	}
+	id := getId(request)
+	err = store.DeleteRecord(ctx, &id, request.ExpectedVersion)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &blacklist.Empty{}, nil
+}
+
+// DeleteBatchBlacklistRecord deletes the items without an expected version as a
+// single batch, the rest need a conditional delete each.
+func (receiver *BlacklistServer) DeleteBatchBlacklistRecord(stream blacklist.Blacklist_DeleteBatchBlacklistRecordServer) error {
+	return receiver.processBatches(stream, nil, func(ctx context.Context, store clients.RecordStore, items []*batchItem) error {
+		unconditional := make([]*batchItem, 0, len(items))
+		conditional := make([]*batchItem, 0, len(items))
+		for _, item := range items {
+			if item.request.ExpectedVersion == 0 {
+				unconditional = append(unconditional, item)
+			} else {
+				conditional = append(conditional, item)
+			}
 		}
-		result, err := client.SaveBatchRecords(records)
-		if err != nil {
-			return err
+		if len(unconditional) > 0 {
+			err := store.DeleteBatchRecords(ctx, uniqueKeys(unconditional))
+			unprocessed, partial := unprocessedIds(err)
+			for _, item := range unconditional {
+				if err != nil && (!partial || unprocessed[item.id]) {
+					item.fail(err)
+				} else {
+					item.succeed(nil)
+				}
+			}
 		}
-		for _, recordResult := range result {
-			err = stream.Send(recordResult.ToDto())
+		forEachParallel(len(conditional), func(index int) {
+			item := conditional[index]
+			err := store.DeleteRecord(ctx, &item.id, item.request.ExpectedVersion)
 			if err != nil {
-				return err
+				item.fail(err)
+			} else {
+				item.succeed(nil)
 			}
-		}
-	}
+		})
+		return nil
+	})
 }
 
-func (receiver *BlacklistServer) DeleteBlacklistRecord(_ context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.Empty, error) {
-	client, err := clients.NewClient(receiver.Table)
+func (receiver *BlacklistServer) GetAllowlistRecord(ctx context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.BlacklistRecordDto, error) {
+	store, err := receiver.store(ctx)
 	if err != nil {
 		return nil, err
 	}
-	id := getIdFromRequest(request)
-	err = client.DeleteRecord(&id)
+	_, err = receiver.getList(ctx, store, request.List)
 	if err != nil {
 		return nil, err
 	}
-	return &blacklist.Empty{}, nil
+	id := getAllowIdFromRequest(request)
+	record, err := store.GetRecordById(ctx, &id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if record == nil {
+		return nil, notFoundError(id)
+	}
+	return record.ToDto(), nil
 }
 
-func (receiver *BlacklistServer) DeleteBatchBlacklistRecord(stream blacklist.Blacklist_DeleteBatchBlacklistRecordServer) error {
-	client, err := clients.NewClient(receiver.Table)
+func (receiver *BlacklistServer) SaveAllowlistRecord(ctx context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.BlacklistRecordDto, error) {
+	return receiver.saveRecord(ctx, request, func(request *blacklist.BlacklistRecordOperationRequest) *models.Record {
+		return models.FromOperationRequest(request).AsAllowlistEntry()
+	})
+}
+
+func (receiver *BlacklistServer) UpdateAllowlistRecord(ctx context.Context, request *blacklist.UpdateBlacklistRecordRequest) (*blacklist.BlacklistRecordDto, error) {
+	return receiver.updateRecord(ctx, request, getAllowIdFromRequest)
+}
+
+func (receiver *BlacklistServer) DeleteAllowlistRecord(ctx context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.Empty, error) {
+	return receiver.deleteRecord(ctx, request, getAllowIdFromRequest)
+}
+
+// newCheckResult builds the result of a check decided by the given block or
+// allowlist entry, nil when none applies.
+func newCheckResult(request *blacklist.BlacklistRecordOperationRequest, decidedBy *blacklist.BlacklistRecordDto) *blacklist.BlacklistCheckResult {
+	result := &blacklist.BlacklistCheckResult{
+		RecordId:  request.RecordId,
+		ClientId:  request.ClientId,
+		ProductId: request.ProductId,
+		DecidedBy: decidedBy,
+	}
+	if decidedBy != nil && decidedBy.Kind == blacklist.RecordKind_BLOCK {
+		result.Blacklisted = true
+		result.Record = decidedBy
+	}
+	return result
+}
+
+func (receiver *BlacklistServer) IsBlacklisted(ctx context.Context, request *blacklist.BlacklistRecordOperationRequest) (*blacklist.BlacklistCheckResult, error) {
+	store, err := receiver.store(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	for {
-		in, err := stream.Recv()
-		ids := make([]*string, 0, receiver.BatchSize)
-		if err == io.EOF {
-			return nil
-		}
-		if err != nil {
-			return err
-		}
-		if len(in.Requests) > receiver.BatchSize {
-			return errors.New(fmt.Sprintf(maxLengthExceeded, receiver.BatchSize, len(in.Requests)))
-		}
-		for _, request := range in.Requests {
-			id := getIdFromRequest(request)
-			ids = append(ids, &id)
-		}
-		err = client.DeleteBatchRecords(ids)
-		if err != nil {
-			return err
-		}
+	_, err = receiver.getList(ctx, store, request.List)
+	if err != nil {
+		return nil, err
+	}
+	record, err := receiver.lookup(ctx, store, getCheckKeys(request))
+	if err != nil {
+		return nil, toStatus(err)
 	}
+	if record == nil {
+		return newCheckResult(request, nil), nil
+	}
+	return newCheckResult(request, record.ToDto()), nil
+}
+
+func (receiver *BlacklistServer) IsBlacklistedBatch(stream blacklist.Blacklist_IsBlacklistedBatchServer) error {
+	return receiver.receiveBatches(stream, getCheckKeys, receiver.lookupItems, func(item *batchItem) error {
+		// failed checks are reported in their own result, so the others still go through
+		result := newCheckResult(item.request, item.result.Record)
+		switch item.result.Status {
+		case blacklist.BatchItemStatus_OK, blacklist.BatchItemStatus_NOT_FOUND:
+			// no entry applying is an answer like any other, not an error
+			result.Status = blacklist.BatchItemStatus_OK
+		default:
+			result.Status = item.result.Status
+			result.Error = item.result.Error
+			result.Code = item.result.Code
+		}
+		return stream.Send(result)
+	})
 }
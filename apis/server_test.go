@@ -0,0 +1,157 @@
+package apis
+
+import (
+	"blacklist/pkg/clients"
+	"blacklist/tools/protos"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// newTestClient serves the server over an in-memory connection, both are
+// closed when the test ends.
+func newTestClient(t *testing.T, server *BlacklistServer, options ...grpc.ServerOption) blacklist.BlacklistClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(options...)
+	blacklist.RegisterBlacklistServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	conn, err := grpc.Dial("bufconn", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.Dial()
+	}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dialing the server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return blacklist.NewBlacklistClient(conn)
+}
+
+// batchItemStream is the client side of the batch RPCs streaming item results.
+type batchItemStream interface {
+	Send(*blacklist.BlacklistBatchRequest) error
+	CloseSend() error
+	Recv() (*blacklist.BlacklistBatchItemResult, error)
+}
+
+func sendBatch(t *testing.T, stream batchItemStream, err error, request *blacklist.BlacklistBatchRequest) []*blacklist.BlacklistBatchItemResult {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("opening the batch: %v", err)
+	}
+	err = stream.Send(request)
+	if err != nil {
+		t.Fatalf("sending the batch: %v", err)
+	}
+	err = stream.CloseSend()
+	if err != nil {
+		t.Fatalf("closing the batch: %v", err)
+	}
+	var results []*blacklist.BlacklistBatchItemResult
+	for {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			return results
+		}
+		if err != nil {
+			t.Fatalf("receiving the batch: %v", err)
+		}
+		results = append(results, result)
+	}
+}
+
+func assertCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Fatalf("error = %v, want code %s", err, code)
+	}
+}
+
+// checkEntry is a block or allowlist entry of record r, client c and product p
+// with the given scope.
+type checkEntry struct {
+	kind    blacklist.RecordKind
+	scope   blacklist.BlockScope
+	expired bool
+}
+
+func TestChecksApplyTheMostSpecificEntry(t *testing.T) {
+	allow, block := blacklist.RecordKind_ALLOW, blacklist.RecordKind_BLOCK
+	tests := []struct {
+		name        string
+		entries     []checkEntry
+		blacklisted bool
+		// decidedBy is the kind of the deciding entry, nil when none applies
+		decidedBy *blacklist.RecordKind
+	}{
+		{"no entry", nil, false, nil},
+		{"exact block", []checkEntry{{block, blacklist.BlockScope_EXACT, false}}, true, &block},
+		{"exact allow beats all products block", []checkEntry{{allow, blacklist.BlockScope_EXACT, false}, {block, blacklist.BlockScope_ALL_PRODUCTS, false}}, false, &allow},
+		{"exact block beats all clients and products allow", []checkEntry{{block, blacklist.BlockScope_EXACT, false}, {allow, blacklist.BlockScope_ALL_CLIENTS_AND_PRODUCTS, false}}, true, &block},
+		{"all products allow beats all clients block", []checkEntry{{allow, blacklist.BlockScope_ALL_PRODUCTS, false}, {block, blacklist.BlockScope_ALL_CLIENTS, false}}, false, &allow},
+		{"allow beats block of the same scope", []checkEntry{{block, blacklist.BlockScope_ALL_CLIENTS, false}, {allow, blacklist.BlockScope_ALL_CLIENTS, false}}, false, &allow},
+		{"expired allow is ignored", []checkEntry{{allow, blacklist.BlockScope_EXACT, true}, {block, blacklist.BlockScope_ALL_PRODUCTS, false}}, true, &block},
+		{"expired block is ignored", []checkEntry{{block, blacklist.BlockScope_EXACT, true}}, false, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, &BlacklistServer{Store: clients.NewMemoryClient(), BatchSize: 100})
+			ctx := context.Background()
+			for _, entry := range test.entries {
+				request := &blacklist.BlacklistRecordOperationRequest{RecordId: "r", ClientId: "c", ProductId: "p", Scope: entry.scope}
+				if entry.expired {
+					request.ExpiresAt = timestamppb.New(time.Now().Add(-time.Hour))
+				}
+				save := client.SaveBlacklistRecord
+				if entry.kind == allow {
+					save = client.SaveAllowlistRecord
+				}
+				_, err := save(ctx, request)
+				if err != nil {
+					t.Fatalf("saving %v: %v", entry, err)
+				}
+			}
+			check := &blacklist.BlacklistRecordOperationRequest{RecordId: "r", ClientId: "c", ProductId: "p"}
+			result, err := client.IsBlacklisted(ctx, check)
+			if err != nil {
+				t.Fatalf("checking: %v", err)
+			}
+			assertCheck(t, "IsBlacklisted", result, test.blacklisted, test.decidedBy)
+			stream, err := client.IsBlacklistedBatch(ctx)
+			if err != nil {
+				t.Fatalf("opening the batch: %v", err)
+			}
+			err = stream.Send(&blacklist.BlacklistBatchRequest{Requests: []*blacklist.BlacklistRecordOperationRequest{check}})
+			if err != nil {
+				t.Fatalf("sending the batch: %v", err)
+			}
+			result, err = stream.Recv()
+			if err != nil {
+				t.Fatalf("receiving the batch: %v", err)
+			}
+			assertCheck(t, "IsBlacklistedBatch", result, test.blacklisted, test.decidedBy)
+		})
+	}
+}
+
+func assertCheck(t *testing.T, rpc string, result *blacklist.BlacklistCheckResult, blacklisted bool, decidedBy *blacklist.RecordKind) {
+	t.Helper()
+	if result.Blacklisted != blacklisted {
+		t.Errorf("%s blacklisted = %t, want %t", rpc, result.Blacklisted, blacklisted)
+	}
+	switch {
+	case decidedBy == nil && result.DecidedBy != nil:
+		t.Errorf("%s decided by %v, want none", rpc, result.DecidedBy)
+	case decidedBy != nil && result.DecidedBy == nil:
+		t.Errorf("%s decided by none, want %s", rpc, *decidedBy)
+	case decidedBy != nil && result.DecidedBy.Kind != *decidedBy:
+		t.Errorf("%s decided by %s, want %s", rpc, result.DecidedBy.Kind, *decidedBy)
+	}
+}
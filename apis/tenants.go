@@ -0,0 +1,134 @@
+package apis
+
+import (
+	"blacklist/models"
+	"blacklist/pkg/clients"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"os"
+	"strings"
+)
+
+const bearerPrefix = "bearer "
+
+var (
+	missingToken  = "requests need an authorization: Bearer <token> header"
+	unknownToken  = "the given token does not belong to any tenant"
+	missingTenant = "the request was not authenticated as any tenant"
+	invalidHash   = "tenant %s has an invalid token hash %q, expected a hex encoded sha256"
+)
+
+type tenantKey struct{}
+
+// Tenants authenticates callers by their bearer token and tells which tenant
+// they belong to. Only the sha256 of every token is kept.
+type Tenants struct {
+	byTokenHash map[string]string
+}
+
+// LoadTenants reads a JSON object mapping the hex encoded sha256 of every token
+// accepted to the tenant it belongs to, a tenant may have several tokens.
+func LoadTenants(path string) (*Tenants, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	byTokenHash := make(map[string]string)
+	err = json.Unmarshal(data, &byTokenHash)
+	if err != nil {
+		return nil, err
+	}
+	return NewTenants(byTokenHash)
+}
+
+func NewTenants(byTokenHash map[string]string) (*Tenants, error) {
+	tenants := &Tenants{byTokenHash: make(map[string]string, len(byTokenHash))}
+	for hash, tenant := range byTokenHash {
+		err := models.ValidateTenantName(tenant)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := hex.DecodeString(hash)
+		if err != nil || len(decoded) != sha256.Size {
+			return nil, errors.New(fmt.Sprintf(invalidHash, tenant, hash))
+		}
+		tenants.byTokenHash[hex.EncodeToString(decoded)] = tenant
+	}
+	return tenants, nil
+}
+
+// authenticate returns the context of a request carrying the tenant of its
+// token, it fails with UNAUTHENTICATED for unknown or missing tokens.
+func (receiver *Tenants) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if len(value) <= len(bearerPrefix) || !strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+			continue
+		}
+		hash := sha256.Sum256([]byte(value[len(bearerPrefix):]))
+		tenant, ok := receiver.byTokenHash[hex.EncodeToString(hash[:])]
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, unknownToken)
+		}
+		return context.WithValue(ctx, tenantKey{}, tenant), nil
+	}
+	return nil, status.Error(codes.Unauthenticated, missingToken)
+}
+
+func (receiver *Tenants) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := receiver.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, request)
+	}
+}
+
+func (receiver *Tenants) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := receiver.authenticate(stream.Context())
+		if err != nil {
+			return err
+		}
+		return handler(server, &tenantStream{stream, ctx})
+	}
+}
+
+// tenantStream replaces the context of a stream with the authenticated one.
+type tenantStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (receiver *tenantStream) Context() context.Context {
+	return receiver.ctx
+}
+
+// TenantFromContext returns the tenant a request was authenticated as.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok
+}
+
+// store returns the store a request works with, confined to the tenant of the
+// caller when the server isolates tenants. Every handler goes through it, so a
+// request missing its tenant is rejected instead of seeing every record.
+func (receiver *BlacklistServer) store(ctx context.Context) (clients.RecordStore, error) {
+	if receiver.Tenants == nil {
+		return receiver.Store, nil
+	}
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, missingTenant)
+	}
+	return clients.NewTenantStore(receiver.Store, tenant), nil
+}
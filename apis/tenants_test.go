@@ -0,0 +1,241 @@
+package apis
+
+import (
+	"blacklist/pkg/clients"
+	"blacklist/tools/protos"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"io"
+	"testing"
+)
+
+const (
+	ownerToken = "owner-token"
+	otherToken = "other-token"
+	ownedList  = "fraud"
+)
+
+func tokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func operation(list, recordId, clientId, productId string) *blacklist.BlacklistRecordOperationRequest {
+	return &blacklist.BlacklistRecordOperationRequest{List: list, RecordId: recordId, ClientId: clientId, ProductId: productId, ReasonCode: "owner"}
+}
+
+// newTenantsClient serves a memory store isolated between the owner and other
+// tenants, the owner holding a record in the default list and one in ownedList.
+func newTenantsClient(t *testing.T) blacklist.BlacklistClient {
+	tenants, err := NewTenants(map[string]string{tokenHash(ownerToken): "owner", tokenHash(otherToken): "other"})
+	if err != nil {
+		t.Fatalf("creating the tenants: %v", err)
+	}
+	server := &BlacklistServer{Store: clients.NewMemoryClient(), BatchSize: 100, Concurrency: 1, Tenants: tenants}
+	client := newTestClient(t, server, grpc.UnaryInterceptor(tenants.UnaryInterceptor()), grpc.StreamInterceptor(tenants.StreamInterceptor()))
+	owner := withToken(ownerToken)
+	_, err = client.CreateList(owner, &blacklist.CreateListRequest{Name: ownedList})
+	if err != nil {
+		t.Fatalf("creating the list: %v", err)
+	}
+	for _, request := range []*blacklist.BlacklistRecordOperationRequest{operation("", "r", "c", "p"), operation(ownedList, "r", "c", "p")} {
+		_, err = client.SaveBlacklistRecord(owner, request)
+		if err != nil {
+			t.Fatalf("saving the owner records: %v", err)
+		}
+	}
+	return client
+}
+
+// assertOwnerRecords fails unless the owner still holds its records untouched.
+func assertOwnerRecords(t *testing.T, client blacklist.BlacklistClient) {
+	t.Helper()
+	for _, list := range []string{"", ownedList} {
+		record, err := client.GetBlacklistRecord(withToken(ownerToken), operation(list, "r", "c", "p"))
+		if err != nil {
+			t.Fatalf("reading the owner record of list %q: %v", list, err)
+		}
+		if record.ReasonCode != "owner" {
+			t.Fatalf("owner record of list %q was overwritten: %v", list, record)
+		}
+	}
+}
+
+func queryCount(t *testing.T, client blacklist.BlacklistClient, ctx context.Context, request *blacklist.BlacklistRecordQueriesRequest) int {
+	t.Helper()
+	stream, err := client.GetBlacklistRecordsQuery(ctx, request)
+	if err != nil {
+		t.Fatalf("querying: %v", err)
+	}
+	count := 0
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			return count
+		}
+		if err != nil {
+			t.Fatalf("querying: %v", err)
+		}
+		count++
+	}
+}
+
+func TestTenantsRejectUnknownCallers(t *testing.T) {
+	client := newTenantsClient(t)
+	for name, ctx := range map[string]context.Context{
+		"missing token": context.Background(),
+		"unknown token": withToken("unknown-token"),
+		"not a bearer":  metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic "+ownerToken),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.GetBlacklistRecord(ctx, operation("", "r", "c", "p"))
+			assertCode(t, err, codes.Unauthenticated)
+			_, err = client.SaveBlacklistRecord(ctx, operation("", "r", "c", "p"))
+			assertCode(t, err, codes.Unauthenticated)
+			_, err = client.DescribeList(ctx, &blacklist.ListRequest{Name: ownedList})
+			assertCode(t, err, codes.Unauthenticated)
+			query, err := client.GetBlacklistRecordsQuery(ctx, &blacklist.BlacklistRecordQueriesRequest{})
+			if err == nil {
+				_, err = query.Recv()
+			}
+			assertCode(t, err, codes.Unauthenticated)
+			batch, err := client.IsBlacklistedBatch(ctx)
+			if err == nil {
+				_, err = batch.Recv()
+			}
+			assertCode(t, err, codes.Unauthenticated)
+		})
+	}
+	assertOwnerRecords(t, client)
+}
+
+func TestTenantsIsolateQueries(t *testing.T) {
+	client := newTenantsClient(t)
+	owner, other := withToken(ownerToken), withToken(otherToken)
+	byClient := &blacklist.BlacklistRecordQueriesRequest{Queries: []*blacklist.BlacklistRecordQueryRequest{{Field: blacklist.SupportedQueryField_client_id, Operation: blacklist.SupportedQueryOperation_EQUALS, Value: "c"}}}
+	if count := queryCount(t, client, other, &blacklist.BlacklistRecordQueriesRequest{}); count != 0 {
+		t.Errorf("other tenant queried %d records, want 0", count)
+	}
+	if count := queryCount(t, client, other, byClient); count != 0 {
+		t.Errorf("other tenant queried %d records by client_id, want 0", count)
+	}
+	if count := queryCount(t, client, owner, byClient); count != 1 {
+		t.Errorf("owner queried %d records by client_id, want 1", count)
+	}
+	_, err := client.SaveBlacklistRecord(other, operation("", "r", "c", "p"))
+	if err != nil {
+		t.Fatalf("saving the same ids as the other tenant: %v", err)
+	}
+	if count := queryCount(t, client, other, byClient); count != 1 {
+		t.Errorf("other tenant queried %d records by client_id, want its own 1", count)
+	}
+	assertOwnerRecords(t, client)
+}
+
+func TestTenantsIsolateRecords(t *testing.T) {
+	client := newTenantsClient(t)
+	other := withToken(otherToken)
+	_, err := client.GetBlacklistRecord(other, operation("", "r", "c", "p"))
+	assertCode(t, err, codes.NotFound)
+	result, err := client.IsBlacklisted(other, operation("", "r", "c", "p"))
+	if err != nil {
+		t.Fatalf("checking: %v", err)
+	}
+	if result.Blacklisted || result.DecidedBy != nil {
+		t.Errorf("other tenant check = %v, want not blacklisted", result)
+	}
+	_, _ = client.DeleteBlacklistRecord(other, operation("", "r", "c", "p"))
+	assertOwnerRecords(t, client)
+}
+
+func TestTenantsIsolateLists(t *testing.T) {
+	client := newTenantsClient(t)
+	other := withToken(otherToken)
+	_, err := client.DescribeList(other, &blacklist.ListRequest{Name: ownedList})
+	assertCode(t, err, codes.NotFound)
+	_, err = client.DeleteList(other, &blacklist.ListRequest{Name: ownedList})
+	assertCode(t, err, codes.NotFound)
+	_, err = client.GetBlacklistRecord(other, operation(ownedList, "r", "c", "p"))
+	assertCode(t, err, codes.NotFound)
+	_, err = client.CreateList(other, &blacklist.CreateListRequest{Name: ownedList})
+	if err != nil {
+		t.Fatalf("creating a list named as the owner one: %v", err)
+	}
+	_, err = client.DeleteList(other, &blacklist.ListRequest{Name: ownedList})
+	if err != nil {
+		t.Fatalf("deleting its own list: %v", err)
+	}
+	_, err = client.DescribeList(withToken(ownerToken), &blacklist.ListRequest{Name: ownedList})
+	if err != nil {
+		t.Fatalf("describing the owner list: %v", err)
+	}
+	assertOwnerRecords(t, client)
+}
+
+func TestTenantsIsolateBatches(t *testing.T) {
+	client := newTenantsClient(t)
+	other := withToken(otherToken)
+	batch := &blacklist.BlacklistBatchRequest{Requests: []*blacklist.BlacklistRecordOperationRequest{operation("", "r", "c", "p")}}
+
+	stream, err := client.GetBlacklistRecordBatch(other)
+	for _, result := range sendBatch(t, stream, err, batch) {
+		if result.Status != blacklist.BatchItemStatus_NOT_FOUND {
+			t.Errorf("other tenant batch get = %v, want NOT_FOUND", result)
+		}
+	}
+
+	checks, err := client.IsBlacklistedBatch(other)
+	if err != nil {
+		t.Fatalf("opening the check batch: %v", err)
+	}
+	err = checks.Send(batch)
+	if err != nil {
+		t.Fatalf("sending the check batch: %v", err)
+	}
+	_ = checks.CloseSend()
+	for {
+		result, err := checks.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("receiving the check batch: %v", err)
+		}
+		if result.Blacklisted || result.DecidedBy != nil || result.Status != blacklist.BatchItemStatus_OK || result.Code != 0 || result.Error != "" {
+			t.Errorf("other tenant batch check = %v, want OK and not blacklisted", result)
+		}
+	}
+
+	stream, err = client.DeleteBatchBlacklistRecord(other)
+	sendBatch(t, stream, err, batch)
+	assertOwnerRecords(t, client)
+
+	stream, err = client.SaveBlacklistRecordBatch(other)
+	overwrite := &blacklist.BlacklistBatchRequest{Requests: []*blacklist.BlacklistRecordOperationRequest{operation("", "r", "c", "p")}}
+	overwrite.Requests[0].ReasonCode = "other"
+	for _, result := range sendBatch(t, stream, err, overwrite) {
+		if result.Status != blacklist.BatchItemStatus_OK {
+			t.Errorf("other tenant batch save = %v, want OK", result)
+		}
+	}
+	assertOwnerRecords(t, client)
+
+	stream, err = client.GetBlacklistRecordBatch(other)
+	if err != nil {
+		t.Fatalf("opening the batch: %v", err)
+	}
+	err = stream.Send(&blacklist.BlacklistBatchRequest{List: ownedList, Requests: []*blacklist.BlacklistRecordOperationRequest{operation(ownedList, "r", "c", "p")}})
+	if err != nil {
+		t.Fatalf("sending the batch: %v", err)
+	}
+	_, err = stream.Recv()
+	assertCode(t, err, codes.NotFound)
+}
@@ -2,33 +2,118 @@ package main
 
 import (
 	"blacklist/apis"
+	"blacklist/pkg/clients"
+	"blacklist/pkg/migrations"
 	"blacklist/tools/protos"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"google.golang.org/grpc"
 	"log"
 	"net"
 	"os"
+	"time"
 )
 
 var (
-	port = flag.Int("port", 50051, "The server port")
+	port     = flag.Int("port", 50051, "The server port")
+	store    = flag.String("store", "dynamodb", "The storage backend (dynamodb, memory, bolt, postgres)")
+	boltPath = flag.String("bolt-path", "blacklist.db", "The database file used by the bolt store")
+
+	maxBatchSize     = flag.Int("max-batch-size", 1000, "The most requests accepted in a single batch message")
+	batchConcurrency = flag.Int("batch-concurrency", 4, "How many chunks of a batch are sent to the store at a time")
+	tenants          = flag.String("tenants", "", "JSON file mapping the sha256 of every accepted bearer token to its tenant, records are isolated by tenant when set")
+	migrate          = flag.String("migrate", "", "Run a one-off migration against the store and exit instead of serving (added-date, rekey)")
+
+	provision          = flag.String("provision", "none", "What to do with the dynamodb table on startup (none, verify, create)")
+	dynamoEndpoint     = flag.String("dynamodb-endpoint", os.Getenv("BLACKLIST_DYNAMODB_ENDPOINT"), "Custom DynamoDB endpoint, e.g. http://localhost:8000 for DynamoDB Local")
+	awsRegion          = flag.String("aws-region", os.Getenv("BLACKLIST_AWS_REGION"), "AWS region used by the dynamodb store")
+	awsAccessKeyId     = flag.String("aws-access-key-id", os.Getenv("BLACKLIST_AWS_ACCESS_KEY_ID"), "Static AWS access key id used by the dynamodb store")
+	awsSecretAccessKey = flag.String("aws-secret-access-key", os.Getenv("BLACKLIST_AWS_SECRET_ACCESS_KEY"), "Static AWS secret access key used by the dynamodb store")
+	awsSessionToken    = flag.String("aws-session-token", os.Getenv("BLACKLIST_AWS_SESSION_TOKEN"), "Static AWS session token used by the dynamodb store")
+	maxIdleConns       = flag.Int("dynamodb-max-idle-conns", 100, "Idle connections kept open to DynamoDB")
+	maxConnsPerHost    = flag.Int("dynamodb-max-conns", 0, "Maximum connections open to DynamoDB, 0 means unlimited")
+	idleConnTimeout    = flag.Duration("dynamodb-idle-conn-timeout", 90*time.Second, "How long an idle connection to DynamoDB is kept open")
 )
 
+func newStore(kind string) (clients.RecordStore, error) {
+	switch kind {
+	case "dynamodb":
+		client, err := clients.NewClient(&clients.DynamoConfig{
+			Table:           os.Getenv("BLACKLIST_TABLE"),
+			ListsTable:      os.Getenv("BLACKLIST_LISTS_TABLE"),
+			Endpoint:        *dynamoEndpoint,
+			Region:          *awsRegion,
+			AccessKeyId:     *awsAccessKeyId,
+			SecretAccessKey: *awsSecretAccessKey,
+			SessionToken:    *awsSessionToken,
+			MaxIdleConns:    *maxIdleConns,
+			MaxConnsPerHost: *maxConnsPerHost,
+			IdleConnTimeout: *idleConnTimeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = provisionTable(client, *provision)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case "memory":
+		return clients.NewMemoryClient(), nil
+	case "bolt":
+		return clients.NewBoltClient(*boltPath)
+	case "postgres":
+		return clients.NewPostgresClient(os.Getenv("BLACKLIST_POSTGRES_DSN"), os.Getenv("BLACKLIST_TABLE"))
+	}
+	return nil, errors.New(fmt.Sprintf("unknown store %s", kind))
+}
+
+func provisionTable(client *clients.BlacklistClient, mode string) error {
+	switch mode {
+	case "none":
+		return nil
+	case "verify":
+		return client.VerifyTable(context.Background())
+	case "create":
+		return client.EnsureTable(context.Background())
+	}
+	return errors.New(fmt.Sprintf("unknown provision mode %s", mode))
+}
+
 func main() {
 	err := os.Setenv("AWS_SDK_LOAD_CONFIG", "true")
 	if err != nil {
 		log.Fatalf("failed to start: %v", err)
 	}
 	flag.Parse()
+	recordStore, err := newStore(*store)
+	if err != nil {
+		log.Fatalf("failed to create store: %v", err)
+	}
+	if *migrate != "" {
+		err = migrations.Run(context.Background(), recordStore, *migrate)
+		if err != nil {
+			log.Fatalf("failed to migrate: %v", err)
+		}
+		return
+	}
 	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", *port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
+	blacklistServer := &apis.BlacklistServer{Store: recordStore, BatchSize: *maxBatchSize, Concurrency: *batchConcurrency}
 	var opts []grpc.ServerOption
+	if *tenants != "" {
+		blacklistServer.Tenants, err = apis.LoadTenants(*tenants)
+		if err != nil {
+			log.Fatalf("failed to load tenants: %v", err)
+		}
+		opts = append(opts, grpc.UnaryInterceptor(blacklistServer.Tenants.UnaryInterceptor()), grpc.StreamInterceptor(blacklistServer.Tenants.StreamInterceptor()))
+	}
 	server := grpc.NewServer(opts...)
-	table := os.Getenv("BLACKLIST_TABLE")
-	blacklist.RegisterBlacklistServer(server, &apis.BlacklistServer{Table: table, BatchSize: 25})
+	blacklist.RegisterBlacklistServer(server, blacklistServer)
 	err = server.Serve(listener)
 	if err != nil {
 		return
@@ -0,0 +1,86 @@
+package models
+
+import (
+	blacklist "blacklist/tools/protos"
+	"strings"
+)
+
+const keySeparator = ":"
+
+// Wildcard is held as client or product id by blocks covering all of them, it
+// is not a valid id of its own.
+const Wildcard = "*"
+
+// allowSuffix follows the key of a block to key the allowlist entry with the
+// same ids, components never hold an unescaped separator.
+const allowSuffix = keySeparator + "allow"
+
+// listSeparator follows the name of a list in the keys of its records. Keys of
+// the default list have two or three unescaped separators and the ones of a
+// named list four or five, list names never hold a separator of their own.
+const listSeparator = keySeparator + keySeparator
+
+// tenantPrefix starts the keys of the records and lists of a tenant. Escaped ids
+// only hold a backslash before another one or a separator and tenant names
+// neither start with nor hold either, so the keys of a tenant never match the
+// ones of another tenant or the ones stored without tenant.
+const tenantPrefix = `\`
+
+var keyEscaper = strings.NewReplacer(`\`, `\\`, keySeparator, `\`+keySeparator)
+
+// RecordKey builds the id a record is stored under. Separators and backslashes
+// inside a component are escaped with a backslash, so different components
+// never end up with the same id.
+func RecordKey(recordId, clientId, productId string) string {
+	return strings.Join([]string{keyEscaper.Replace(recordId), keyEscaper.Replace(clientId), keyEscaper.Replace(productId)}, keySeparator)
+}
+
+// AllowKey returns the key of the allowlist entry with the same ids as the
+// block stored under key.
+func AllowKey(key string) string {
+	return key + allowSuffix
+}
+
+// ListKey returns the key a record stored under key in the default list has in
+// the given list, the default list keeps the key as it is.
+func ListKey(list, key string) string {
+	if list == "" {
+		return key
+	}
+	return list + listSeparator + key
+}
+
+// TenantKey returns the key a record or list stored under key has when owned by
+// the given tenant, the key is kept as it is without tenant.
+func TenantKey(tenant, key string) string {
+	if tenant == "" {
+		return key
+	}
+	return tenantPrefix + tenant + keySeparator + key
+}
+
+// ScopedIds returns the ids a request addresses, with Wildcard in place of the
+// client or product its scope covers entirely.
+func ScopedIds(request *blacklist.BlacklistRecordOperationRequest) (string, string, string) {
+	clientId, productId := request.ClientId, request.ProductId
+	switch request.Scope {
+	case blacklist.BlockScope_ALL_PRODUCTS:
+		productId = Wildcard
+	case blacklist.BlockScope_ALL_CLIENTS:
+		clientId = Wildcard
+	case blacklist.BlockScope_ALL_CLIENTS_AND_PRODUCTS:
+		clientId, productId = Wildcard, Wildcard
+	}
+	return request.RecordId, clientId, productId
+}
+
+// CandidateKeys are the keys of every block applying to a specific record,
+// client and product, from the most to the least specific one.
+func CandidateKeys(recordId, clientId, productId string) []string {
+	return []string{
+		RecordKey(recordId, clientId, productId),
+		RecordKey(recordId, clientId, Wildcard),
+		RecordKey(recordId, Wildcard, productId),
+		RecordKey(recordId, Wildcard, Wildcard),
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import "testing"
+
+func TestTenantKeysNeverCollide(t *testing.T) {
+	// ids crafted to look like the keys of another tenant once escaped
+	ids := [][3]string{
+		{"r", "c", "p"},
+		{`\acme`, "c", "p"},
+		{`\acme:r`, "c", "p"},
+		{`acme:r`, "c", "p"},
+		{`\`, `\`, `\`},
+		{"r", "c", Wildcard},
+	}
+	tenants := []string{"", "acme", "acme-r", "beta"}
+	lists := []string{"", "fraud"}
+	owners := make(map[string]string)
+	for _, tenant := range tenants {
+		for _, list := range lists {
+			for _, id := range ids {
+				for _, key := range []string{RecordKey(id[0], id[1], id[2]), AllowKey(RecordKey(id[0], id[1], id[2]))} {
+					owner := tenant + "/" + list + "/" + key
+					tenantKey := TenantKey(tenant, ListKey(list, key))
+					if previous, ok := owners[tenantKey]; ok {
+						t.Fatalf("%s and %s share the key %q", previous, owner, tenantKey)
+					}
+					owners[tenantKey] = owner
+				}
+			}
+		}
+	}
+}
+
+func TestTenantKeyWithoutTenant(t *testing.T) {
+	key := RecordKey("r", "c", "p")
+	if got := TenantKey("", key); got != key {
+		t.Errorf("TenantKey without tenant = %q, want %q", got, key)
+	}
+	if got, want := TenantKey("acme", key), `\acme:r:c:p`; got != want {
+		t.Errorf("TenantKey = %q, want %q", got, want)
+	}
+}
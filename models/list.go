@@ -0,0 +1,185 @@
+package models
+
+import (
+	blacklist "blacklist/tools/protos"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	listNamePattern     = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+	invalidListName     = "list name %q must be made of lowercase letters, digits, - and _, up to 64 characters"
+	invalidTenantName   = "tenant name %q must be made of lowercase letters, digits, - and _, up to 64 characters"
+	invalidDefaultTtl   = "default_ttl of list %s must be a positive duration"
+	invalidMaxBatchSize = "max_batch_size of list %s can not be negative"
+)
+
+// List is a named set of records kept apart from the ones of other lists in
+// the same store. The default list has an empty name and no configuration.
+type List struct {
+	name string
+	// defaultTtl is applied to the records saved without an expiration, none when 0
+	defaultTtl time.Duration
+	// maxBatchSize lowers the most requests of a batch message when above 0
+	maxBatchSize int
+	createdAt    time.Time
+}
+
+type listJson struct {
+	Name              string    `json:"name"`
+	DefaultTtlSeconds int64     `json:"default_ttl_seconds,omitempty"`
+	MaxBatchSize      int       `json:"max_batch_size,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// DefaultList is the list of the requests not naming one, it always exists.
+var DefaultList = &List{}
+
+// ValidateListName fails for names a list can not be created with, names never
+// hold a key separator.
+func ValidateListName(name string) error {
+	if !listNamePattern.MatchString(name) {
+		return errors.New(fmt.Sprintf(invalidListName, name))
+	}
+	return nil
+}
+
+// ValidateTenantName fails for names a tenant can not have, tenants follow the
+// same rules as lists.
+func ValidateTenantName(name string) error {
+	if !listNamePattern.MatchString(name) {
+		return errors.New(fmt.Sprintf(invalidTenantName, name))
+	}
+	return nil
+}
+
+func FromCreateListRequest(request *blacklist.CreateListRequest) (*List, error) {
+	err := ValidateListName(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	list := &List{name: request.Name, maxBatchSize: int(request.MaxBatchSize), createdAt: time.Now().UTC()}
+	if request.DefaultTtl != nil {
+		list.defaultTtl = request.DefaultTtl.AsDuration().Truncate(time.Second)
+		if !request.DefaultTtl.IsValid() || list.defaultTtl <= 0 {
+			return nil, errors.New(fmt.Sprintf(invalidDefaultTtl, request.Name))
+		}
+	}
+	if list.maxBatchSize < 0 {
+		return nil, errors.New(fmt.Sprintf(invalidMaxBatchSize, request.Name))
+	}
+	return list, nil
+}
+
+func (receiver *List) Name() string {
+	return receiver.name
+}
+
+// WithName returns a copy of the list stored under the given name.
+func (receiver *List) WithName(name string) *List {
+	list := *receiver
+	list.name = name
+	return &list
+}
+
+func (receiver *List) DefaultTtl() time.Duration {
+	return receiver.defaultTtl
+}
+
+func (receiver *List) MaxBatchSize() int {
+	return receiver.maxBatchSize
+}
+
+func (receiver *List) CreatedAt() time.Time {
+	return receiver.createdAt
+}
+
+// WithDefaults returns a copy of the record carrying the default ttl of the
+// list. Stores only apply it to records left without expiration, an upsert
+// keeps the one of the live record stored.
+func (receiver *List) WithDefaults(record *Record) *Record {
+	return record.WithDefaultTtl(receiver.defaultTtl)
+}
+
+// Query matches the records of the list, which queries are always narrowed to.
+func (receiver *List) Query() *Query {
+	return &Query{Field: ListAttribute, Operand: "EQUALS", Value: receiver.name}
+}
+
+func FromDynamoListItem(item map[string]*dynamodb.AttributeValue) (*List, error) {
+	list := &List{name: stringAttribute(item, "name")}
+	createdAt, err := time.Parse(time.RFC3339Nano, stringAttribute(item, "created_at"))
+	if err != nil {
+		return nil, err
+	}
+	list.createdAt = createdAt
+	if defaultTtl, ok := item["default_ttl_seconds"]; ok && defaultTtl.N != nil {
+		seconds, err := strconv.ParseInt(*defaultTtl.N, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		list.defaultTtl = time.Duration(seconds) * time.Second
+	}
+	if maxBatchSize, ok := item["max_batch_size"]; ok && maxBatchSize.N != nil {
+		list.maxBatchSize, err = strconv.Atoi(*maxBatchSize.N)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func (receiver *List) ToDynamoItem() map[string]*dynamodb.AttributeValue {
+	item := make(map[string]*dynamodb.AttributeValue)
+	setStringAttribute(item, "name", receiver.name)
+	setStringAttribute(item, "created_at", receiver.createdAt.Format(time.RFC3339Nano))
+	if receiver.defaultTtl > 0 {
+		seconds := strconv.FormatInt(int64(receiver.defaultTtl/time.Second), 10)
+		item["default_ttl_seconds"] = &dynamodb.AttributeValue{N: &seconds}
+	}
+	if receiver.maxBatchSize > 0 {
+		maxBatchSize := strconv.Itoa(receiver.maxBatchSize)
+		item["max_batch_size"] = &dynamodb.AttributeValue{N: &maxBatchSize}
+	}
+	return item
+}
+
+func (receiver *List) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&listJson{
+		Name:              receiver.name,
+		DefaultTtlSeconds: int64(receiver.defaultTtl / time.Second),
+		MaxBatchSize:      receiver.maxBatchSize,
+		CreatedAt:         receiver.createdAt,
+	})
+}
+
+func (receiver *List) UnmarshalJSON(data []byte) error {
+	var item listJson
+	err := json.Unmarshal(data, &item)
+	if err != nil {
+		return err
+	}
+	receiver.name = item.Name
+	receiver.defaultTtl = time.Duration(item.DefaultTtlSeconds) * time.Second
+	receiver.maxBatchSize = item.MaxBatchSize
+	receiver.createdAt = item.CreatedAt.UTC()
+	return nil
+}
+
+func (receiver *List) ToDto() *blacklist.ListDto {
+	dto := &blacklist.ListDto{Name: receiver.name, MaxBatchSize: int32(receiver.maxBatchSize)}
+	if receiver.defaultTtl > 0 {
+		dto.DefaultTtl = durationpb.New(receiver.defaultTtl)
+	}
+	if !receiver.createdAt.IsZero() {
+		dto.CreatedAt = timestamppb.New(receiver.createdAt)
+	}
+	return dto
+}
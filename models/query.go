@@ -1,6 +1,9 @@
 package models
 
-import blacklist "blacklist/tools/protos"
+import (
+	blacklist "blacklist/tools/protos"
+	"strings"
+)
 
 type Query struct {
 	Field   string
@@ -9,7 +12,26 @@ type Query struct {
 }
 
 func FromQueryRequest(request *blacklist.BlacklistRecordQueryRequest) *Query {
-	return &Query{Field: request.Field.String(), Operand: request.Operation.String(), Value: request.Value}
+	query := &Query{Field: request.Field.String(), Operand: request.Operation.String(), Value: request.Value}
+	if query.Operand != "BEGINS_WITH" {
+		query.Value = normalizeAddedDate(query.Field, query.Value)
+	}
+	return query
+}
+
+func (receiver *Query) Matches(record *Record) bool {
+	value := record.Value(receiver.Field)
+	switch receiver.Operand {
+	case "EQUALS":
+		return value == receiver.Value
+	case "GREATER_THAN":
+		return value > receiver.Value
+	case "LESSER_THAN":
+		return value < receiver.Value
+	case "BEGINS_WITH":
+		return strings.HasPrefix(value, receiver.Value)
+	}
+	return false
 }
 
 type BetweenQuery struct {
@@ -19,9 +41,29 @@ type BetweenQuery struct {
 }
 
 func FromQueryBetweenRequest(request *blacklist.BlacklistRecordBetweenRequest) *BetweenQuery {
+	field := request.Field.String()
 	return &BetweenQuery{
-		Field: request.Field.String(),
-		Init:  request.Init,
-		End:   request.End,
+		Field: field,
+		Init:  normalizeAddedDate(field, request.Init),
+		End:   normalizeAddedDate(field, request.End),
+	}
+}
+
+func (receiver *BetweenQuery) Matches(record *Record) bool {
+	value := record.Value(receiver.Field)
+	return value >= receiver.Init && value <= receiver.End
+}
+
+func MatchesAll(record *Record, queries []*Query, betweenQueries []*BetweenQuery) bool {
+	for _, query := range queries {
+		if !query.Matches(record) {
+			return false
+		}
+	}
+	for _, betweenQuery := range betweenQueries {
+		if !betweenQuery.Matches(record) {
+			return false
+		}
 	}
+	return true
 }
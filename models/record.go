@@ -2,51 +2,503 @@ package models
 
 import (
 	blacklist "blacklist/tools/protos"
-	"fmt"
+	"encoding/json"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
 	"time"
 )
 
+// TtlAttribute holds the expiration of a record as epoch seconds, as DynamoDB TTL requires.
+const TtlAttribute = "expires_at"
+
+// VersionAttribute counts the writes of a record, stores increment it on every save.
+const VersionAttribute = "version"
+
+// ListAttribute names the list of a record, it is absent for the default list.
+const ListAttribute = "list"
+
+// TenantAttribute names the tenant owning a record, it is absent when the
+// records are not isolated by tenant.
+const TenantAttribute = "tenant"
+
+// KindAttribute holds the kind of a record, it is absent for blocks.
+const KindAttribute = "kind"
+
+// SaveMode tells how a save treats a record already stored under the same id,
+// expired records count as absent.
+type SaveMode int
+
+const (
+	// SaveUpsert keeps the added date, creator and any metadata left empty of
+	// the stored record
+	SaveUpsert SaveMode = iota
+	// SaveCreate fails when a record is already stored
+	SaveCreate
+	// SaveOverwrite replaces the whole stored record
+	SaveOverwrite
+)
+
+func SaveModeFromRequest(request *blacklist.BlacklistRecordOperationRequest) SaveMode {
+	switch request.Mode {
+	case blacklist.SaveMode_CREATE:
+		return SaveCreate
+	case blacklist.SaveMode_OVERWRITE:
+		return SaveOverwrite
+	}
+	return SaveUpsert
+}
+
 type Record struct {
 	recordId  string
 	clientId  string
 	productId string
-	addedDate string
+	addedDate time.Time
+	updatedAt time.Time
+	expiresAt time.Time
+	version   int64
+	storedId  string
+	kind      blacklist.RecordKind
+	list      string
+	tenant    string
+	// Metadata explaining why, from where and by whom the record was blocked
+	reasonCode string
+	note       string
+	source     string
+	createdBy  string
+	labels     map[string]string
+	// defaultTtl is how long after added_date the record expires when saved
+	// without an expiration and none is kept from the stored one, never stored
+	defaultTtl time.Duration
 }
 
 func NewRecord(recordId, clientId, productId string) *Record {
-	return &Record{recordId: recordId, clientId: clientId, productId: productId, addedDate: time.Now().String()}
+	now := time.Now().UTC()
+	return &Record{recordId: recordId, clientId: clientId, productId: productId, addedDate: now, updatedAt: now}
+}
+
+func FromOperationRequest(request *blacklist.BlacklistRecordOperationRequest) *Record {
+	record := NewRecord(ScopedIds(request))
+	record.list = request.List
+	if request.ExpiresAt != nil {
+		record.expiresAt = request.ExpiresAt.AsTime().Truncate(time.Second)
+	}
+	record.reasonCode = request.ReasonCode
+	record.note = request.Note
+	record.source = request.Source
+	record.createdBy = request.CreatedBy
+	record.labels = copyLabels(request.Labels)
+	return record
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+	return copied
+}
+
+type recordJson struct {
+	// StoredId is only read, from stores keeping the id next to the record
+	StoredId   string            `json:"id,omitempty"`
+	RecordId   string            `json:"record_id"`
+	ClientId   string            `json:"client_id"`
+	ProductId  string            `json:"product_id"`
+	AddedDate  string            `json:"added_date"`
+	UpdatedAt  string            `json:"updated_at,omitempty"`
+	Kind       string            `json:"kind,omitempty"`
+	List       string            `json:"list,omitempty"`
+	Tenant     string            `json:"tenant,omitempty"`
+	Version    int64             `json:"version,omitempty"`
+	ExpiresAt  *time.Time        `json:"expires_at,omitempty"`
+	ReasonCode string            `json:"reason_code,omitempty"`
+	Note       string            `json:"note,omitempty"`
+	Source     string            `json:"source,omitempty"`
+	CreatedBy  string            `json:"created_by,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
 }
 
 func (receiver *Record) Id() string {
-	return fmt.Sprintf("%s:%s:%s", receiver.recordId, receiver.clientId, receiver.productId)
+	key := RecordKey(receiver.recordId, receiver.clientId, receiver.productId)
+	if receiver.kind == blacklist.RecordKind_ALLOW {
+		key = AllowKey(key)
+	}
+	return TenantKey(receiver.tenant, ListKey(receiver.list, key))
+}
+
+func (receiver *Record) List() string {
+	return receiver.list
+}
+
+func (receiver *Record) Tenant() string {
+	return receiver.tenant
+}
+
+// WithTenant returns a copy of the record owned by the given tenant.
+func (receiver *Record) WithTenant(tenant string) *Record {
+	record := *receiver
+	record.tenant = tenant
+	return &record
+}
+
+func (receiver *Record) Kind() blacklist.RecordKind {
+	return receiver.kind
+}
+
+// AsAllowlistEntry returns a copy of the record turned into an allowlist entry.
+func (receiver *Record) AsAllowlistEntry() *Record {
+	record := *receiver
+	record.kind = blacklist.RecordKind_ALLOW
+	return &record
+}
+
+// kindName is how the kind is stored, empty for blocks.
+func (receiver *Record) kindName() string {
+	if receiver.kind == blacklist.RecordKind_BLOCK {
+		return ""
+	}
+	return receiver.kind.String()
+}
+
+// BlocksQuery matches blocks only, leaving out allowlist entries.
+func BlocksQuery() *Query {
+	return &Query{Field: KindAttribute, Operand: "EQUALS", Value: ""}
+}
+
+func parseKind(name string) blacklist.RecordKind {
+	return blacklist.RecordKind(blacklist.RecordKind_value[name])
+}
+
+func (receiver *Record) Scope() blacklist.BlockScope {
+	switch {
+	case receiver.clientId == Wildcard && receiver.productId == Wildcard:
+		return blacklist.BlockScope_ALL_CLIENTS_AND_PRODUCTS
+	case receiver.clientId == Wildcard:
+		return blacklist.BlockScope_ALL_CLIENTS
+	case receiver.productId == Wildcard:
+		return blacklist.BlockScope_ALL_PRODUCTS
+	}
+	return blacklist.BlockScope_EXACT
+}
+
+// StoredId is the id the record was read under, which differs from Id for
+// records written with an older key format. It is empty when unknown.
+func (receiver *Record) StoredId() string {
+	return receiver.storedId
+}
+
+// WithStoredId returns a copy of the record read under the given id.
+func (receiver *Record) WithStoredId(id string) *Record {
+	record := *receiver
+	record.storedId = id
+	return &record
+}
+
+func (receiver *Record) Value(field string) string {
+	switch field {
+	case "record_id":
+		return receiver.recordId
+	case "client_id":
+		return receiver.clientId
+	case "product_id":
+		return receiver.productId
+	case "added_date":
+		return FormatAddedDate(receiver.addedDate)
+	case "updated_at":
+		if receiver.updatedAt.IsZero() {
+			return ""
+		}
+		return FormatAddedDate(receiver.updatedAt)
+	case "reason_code":
+		return receiver.reasonCode
+	case "source":
+		return receiver.source
+	case "note":
+		return receiver.note
+	case "created_by":
+		return receiver.createdBy
+	case KindAttribute:
+		return receiver.kindName()
+	case ListAttribute:
+		return receiver.list
+	case TenantAttribute:
+		return receiver.tenant
+	}
+	return ""
+}
+
+func (receiver *Record) Labels() map[string]string {
+	return receiver.labels
+}
+
+func (receiver *Record) AddedDate() time.Time {
+	return receiver.addedDate
+}
+
+func (receiver *Record) UpdatedAt() time.Time {
+	return receiver.updatedAt
+}
+
+func (receiver *Record) ExpiresAt() time.Time {
+	return receiver.expiresAt
+}
+
+// WithDefaultTtl returns a copy of the record expiring after the given ttl
+// when it ends up saved without an expiration.
+func (receiver *Record) WithDefaultTtl(ttl time.Duration) *Record {
+	record := *receiver
+	record.defaultTtl = ttl
+	return &record
+}
+
+// DefaultExpiresAt is when the record expires if saved without expiration,
+// zero when it has no default ttl.
+func (receiver *Record) DefaultExpiresAt() time.Time {
+	if receiver.defaultTtl <= 0 {
+		return time.Time{}
+	}
+	return receiver.addedDate.Add(receiver.defaultTtl).Truncate(time.Second)
+}
+
+// WithDefaultExpiration returns a copy of the record expiring at
+// DefaultExpiresAt unless it already expires on its own.
+func (receiver *Record) WithDefaultExpiration() *Record {
+	record := *receiver
+	if record.expiresAt.IsZero() {
+		record.expiresAt = receiver.DefaultExpiresAt()
+	}
+	record.defaultTtl = 0
+	return &record
+}
+
+func (receiver *Record) Version() int64 {
+	return receiver.version
+}
+
+// WithVersion returns a copy of the record holding the given version.
+func (receiver *Record) WithVersion(version int64) *Record {
+	record := *receiver
+	record.version = version
+	return &record
+}
+
+// Preserving returns a copy of the record which keeps the added date and
+// creator of the stored one, as well as any metadata the record leaves empty.
+func (receiver *Record) Preserving(stored *Record) *Record {
+	record := *receiver
+	record.addedDate = stored.addedDate
+	if stored.createdBy != "" {
+		record.createdBy = stored.createdBy
+	}
+	if record.expiresAt.IsZero() {
+		record.expiresAt = stored.expiresAt
+	}
+	if record.reasonCode == "" {
+		record.reasonCode = stored.reasonCode
+	}
+	if record.note == "" {
+		record.note = stored.note
+	}
+	if record.source == "" {
+		record.source = stored.source
+	}
+	if len(record.labels) == 0 {
+		record.labels = copyLabels(stored.labels)
+	}
+	return &record
+}
+
+// Expired tells if the record expired at the given time. Expired records may
+// still be stored, DynamoDB only deletes them eventually, but every read path
+// treats them as absent.
+func (receiver *Record) Expired(now time.Time) bool {
+	return !receiver.expiresAt.IsZero() && !now.Before(receiver.expiresAt)
 }
 
 func FromDynamoItem(item map[string]*dynamodb.AttributeValue) (*Record, error) {
-	return &Record{
+	addedDate, err := ParseAddedDate(*item["added_date"].S)
+	if err != nil {
+		return nil, err
+	}
+	record := &Record{
+		storedId:  stringAttribute(item, "id"),
 		recordId:  *item["record_id"].S,
 		clientId:  *item["client_id"].S,
 		productId: *item["product_id"].S,
-		addedDate: *item["added_date"].S,
-	}, nil
+		addedDate: addedDate,
+	}
+	if expiresAt, ok := item[TtlAttribute]; ok && expiresAt.N != nil {
+		seconds, err := strconv.ParseInt(*expiresAt.N, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		record.expiresAt = time.Unix(seconds, 0).UTC()
+	}
+	if updatedAt := stringAttribute(item, "updated_at"); updatedAt != "" {
+		record.updatedAt, err = ParseAddedDate(updatedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if version, ok := item[VersionAttribute]; ok && version.N != nil {
+		record.version, err = strconv.ParseInt(*version.N, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	record.kind = parseKind(stringAttribute(item, KindAttribute))
+	record.list = stringAttribute(item, ListAttribute)
+	record.tenant = stringAttribute(item, TenantAttribute)
+	record.reasonCode = stringAttribute(item, "reason_code")
+	record.note = stringAttribute(item, "note")
+	record.source = stringAttribute(item, "source")
+	record.createdBy = stringAttribute(item, "created_by")
+	if labels, ok := item["labels"]; ok && labels.M != nil {
+		record.labels = make(map[string]string, len(labels.M))
+		for key, value := range labels.M {
+			record.labels[key] = *value.S
+		}
+	}
+	return record, nil
+}
+
+func stringAttribute(item map[string]*dynamodb.AttributeValue, name string) string {
+	if value, ok := item[name]; ok && value.S != nil {
+		return *value.S
+	}
+	return ""
+}
+
+func setStringAttribute(item map[string]*dynamodb.AttributeValue, name string, value string) {
+	// DynamoDB does not allow empty strings on attributes used as index keys, so
+	// optional attributes are left out instead
+	if value != "" {
+		item[name] = &dynamodb.AttributeValue{S: &value}
+	}
 }
 
 func (receiver *Record) ToDynamoItem() map[string]*dynamodb.AttributeValue {
+	// scoped blocks hold Wildcard as client or product id, in the key as well
 	id := receiver.Id()
 	record := make(map[string]*dynamodb.AttributeValue)
 	record["id"] = &dynamodb.AttributeValue{S: &id}
 	record["record_id"] = &dynamodb.AttributeValue{S: &receiver.recordId}
 	record["client_id"] = &dynamodb.AttributeValue{S: &receiver.clientId}
 	record["product_id"] = &dynamodb.AttributeValue{S: &receiver.productId}
-	record["added_date"] = &dynamodb.AttributeValue{S: &receiver.addedDate}
+	addedDate := FormatAddedDate(receiver.addedDate)
+	record["added_date"] = &dynamodb.AttributeValue{S: &addedDate}
+	if !receiver.expiresAt.IsZero() {
+		expiresAt := strconv.FormatInt(receiver.expiresAt.Unix(), 10)
+		record[TtlAttribute] = &dynamodb.AttributeValue{N: &expiresAt}
+	}
+	if receiver.version != 0 {
+		version := strconv.FormatInt(receiver.version, 10)
+		record[VersionAttribute] = &dynamodb.AttributeValue{N: &version}
+	}
+	setStringAttribute(record, "updated_at", receiver.Value("updated_at"))
+	setStringAttribute(record, KindAttribute, receiver.kindName())
+	setStringAttribute(record, ListAttribute, receiver.list)
+	setStringAttribute(record, TenantAttribute, receiver.tenant)
+	setStringAttribute(record, "reason_code", receiver.reasonCode)
+	setStringAttribute(record, "note", receiver.note)
+	setStringAttribute(record, "source", receiver.source)
+	setStringAttribute(record, "created_by", receiver.createdBy)
+	if len(receiver.labels) > 0 {
+		labels := make(map[string]*dynamodb.AttributeValue, len(receiver.labels))
+		for key, value := range receiver.labels {
+			value := value
+			labels[key] = &dynamodb.AttributeValue{S: &value}
+		}
+		record["labels"] = &dynamodb.AttributeValue{M: labels}
+	}
 	return record
 }
 
+func (receiver *Record) MarshalJSON() ([]byte, error) {
+	item := &recordJson{
+		RecordId:   receiver.recordId,
+		ClientId:   receiver.clientId,
+		ProductId:  receiver.productId,
+		AddedDate:  FormatAddedDate(receiver.addedDate),
+		UpdatedAt:  receiver.Value("updated_at"),
+		Version:    receiver.version,
+		Kind:       receiver.kindName(),
+		List:       receiver.list,
+		Tenant:     receiver.tenant,
+		ReasonCode: receiver.reasonCode,
+		Note:       receiver.note,
+		Source:     receiver.source,
+		CreatedBy:  receiver.createdBy,
+		Labels:     receiver.labels,
+	}
+	if !receiver.expiresAt.IsZero() {
+		item.ExpiresAt = &receiver.expiresAt
+	}
+	return json.Marshal(item)
+}
+
+func (receiver *Record) UnmarshalJSON(data []byte) error {
+	var item recordJson
+	err := json.Unmarshal(data, &item)
+	if err != nil {
+		return err
+	}
+	receiver.storedId = item.StoredId
+	receiver.recordId = item.RecordId
+	receiver.clientId = item.ClientId
+	receiver.productId = item.ProductId
+	receiver.addedDate, err = ParseAddedDate(item.AddedDate)
+	if err != nil {
+		return err
+	}
+	receiver.updatedAt = time.Time{}
+	if item.UpdatedAt != "" {
+		receiver.updatedAt, err = ParseAddedDate(item.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	receiver.version = item.Version
+	receiver.kind = parseKind(item.Kind)
+	receiver.list = item.List
+	receiver.tenant = item.Tenant
+	receiver.expiresAt = time.Time{}
+	if item.ExpiresAt != nil {
+		receiver.expiresAt = item.ExpiresAt.UTC()
+	}
+	receiver.reasonCode = item.ReasonCode
+	receiver.note = item.Note
+	receiver.source = item.Source
+	receiver.createdBy = item.CreatedBy
+	receiver.labels = copyLabels(item.Labels)
+	return nil
+}
+
 func (receiver *Record) ToDto() *blacklist.BlacklistRecordDto {
-	return &blacklist.BlacklistRecordDto{
-		RecordId:  receiver.recordId,
-		ClientId:  receiver.clientId,
-		ProductId: receiver.productId,
-		AddedDate: receiver.addedDate,
+	dto := &blacklist.BlacklistRecordDto{
+		RecordId:   receiver.recordId,
+		ClientId:   receiver.clientId,
+		ProductId:  receiver.productId,
+		AddedDate:  timestamppb.New(receiver.addedDate),
+		Version:    receiver.version,
+		Scope:      receiver.Scope(),
+		Kind:       receiver.kind,
+		List:       receiver.list,
+		ReasonCode: receiver.reasonCode,
+		Note:       receiver.note,
+		Source:     receiver.source,
+		CreatedBy:  receiver.createdBy,
+		Labels:     copyLabels(receiver.labels),
+	}
+	if !receiver.updatedAt.IsZero() {
+		dto.UpdatedAt = timestamppb.New(receiver.updatedAt)
+	}
+	if !receiver.expiresAt.IsZero() {
+		dto.ExpiresAt = timestamppb.New(receiver.expiresAt)
 	}
+	return dto
 }
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// AddedDateLayout is RFC3339 in UTC with a fixed number of fractional digits,
+// so stored dates sort lexicographically in the same order as in time.
+const AddedDateLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// legacyAddedDateLayout is what time.Time.String() produced, the format records
+// were stored with before AddedDateLayout.
+const legacyAddedDateLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+func FormatAddedDate(date time.Time) string {
+	return date.UTC().Format(AddedDateLayout)
+}
+
+// ParseAddedDate reads any RFC3339 date as well as the legacy format, including
+// its monotonic clock suffix.
+func ParseAddedDate(value string) (time.Time, error) {
+	date, err := time.Parse(time.RFC3339Nano, value)
+	if err == nil {
+		return date.UTC(), nil
+	}
+	if index := strings.Index(value, " m="); index >= 0 {
+		value = value[:index]
+	}
+	date, legacyErr := time.Parse(legacyAddedDateLayout, value)
+	if legacyErr != nil {
+		return time.Time{}, err
+	}
+	return date.UTC(), nil
+}
+
+// normalizeAddedDate rewrites a date given in a query to AddedDateLayout so it
+// compares with the stored ones, values which are not a full date are kept.
+func normalizeAddedDate(field, value string) string {
+	if field != "added_date" {
+		return value
+	}
+	date, err := ParseAddedDate(value)
+	if err != nil {
+		return value
+	}
+	return FormatAddedDate(date)
+}
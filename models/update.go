@@ -0,0 +1,76 @@
+package models
+
+import (
+	blacklist "blacklist/tools/protos"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	missingUpdateMask = "update_mask is required"
+	unsupportedUpdate = "field %s can not be updated"
+)
+
+var updatableFields = []string{TtlAttribute, "reason_code", "note", "source", "labels"}
+
+// RecordUpdate sets the listed fields of a stored record to the values of the
+// update, a field without value is cleared.
+type RecordUpdate struct {
+	Fields []string
+	values *Record
+}
+
+func FromUpdateRequest(request *blacklist.UpdateBlacklistRecordRequest) (*RecordUpdate, error) {
+	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
+		return nil, errors.New(missingUpdateMask)
+	}
+	fields := make([]string, 0, len(request.UpdateMask.Paths))
+	seen := make(map[string]bool, len(request.UpdateMask.Paths))
+	for _, path := range request.UpdateMask.Paths {
+		if !isUpdatable(path) {
+			return nil, errors.New(fmt.Sprintf(unsupportedUpdate, path))
+		}
+		if !seen[path] {
+			seen[path] = true
+			fields = append(fields, path)
+		}
+	}
+	return &RecordUpdate{Fields: fields, values: FromOperationRequest(request.Record)}, nil
+}
+
+func isUpdatable(field string) bool {
+	for _, updatable := range updatableFields {
+		if updatable == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Values holds the new values of the updated fields, as a record.
+func (receiver *RecordUpdate) Values() *Record {
+	return receiver.values
+}
+
+// Apply returns a copy of the record with the update applied and updated_at set
+// to now, the version is left to the store.
+func (receiver *RecordUpdate) Apply(record *Record, now time.Time) *Record {
+	updated := *record
+	for _, field := range receiver.Fields {
+		switch field {
+		case TtlAttribute:
+			updated.expiresAt = receiver.values.expiresAt
+		case "reason_code":
+			updated.reasonCode = receiver.values.reasonCode
+		case "note":
+			updated.note = receiver.values.note
+		case "source":
+			updated.source = receiver.values.source
+		case "labels":
+			updated.labels = copyLabels(receiver.values.labels)
+		}
+	}
+	updated.updatedAt = now.UTC()
+	return &updated
+}
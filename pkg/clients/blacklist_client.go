@@ -2,47 +2,135 @@ package clients
 
 import (
 	"blacklist/models"
+	"context"
+	"encoding/json"
 	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"log"
+	"net/http"
+	"time"
+)
+
+const rangeKey = "added_date"
+
+var (
+	queryIndexes = map[string]string{
+		"client_id":  "client_id-added_date-index",
+		"product_id": "product_id-added_date-index",
+	}
+	throttlingCodes = map[string]bool{
+		dynamodb.ErrCodeProvisionedThroughputExceededException: true,
+		dynamodb.ErrCodeRequestLimitExceeded:                   true,
+		dynamodb.ErrCodeLimitExceededException:                 true,
+		"ThrottlingException":                                  true,
+	}
+	unavailableCodes = map[string]bool{
+		dynamodb.ErrCodeInternalServerError:    true,
+		dynamodb.ErrCodeResourceInUseException: true,
+		"ServiceUnavailable":                   true,
+		request.ErrCodeRequestError:            true,
+		request.ErrCodeResponseTimeout:         true,
+		request.ErrCodeRead:                    true,
+	}
+	invalidRequestCodes = map[string]bool{
+		"ValidationException":           true,
+		request.InvalidParameterErrCode: true,
+		request.ParamRequiredErrCode:    true,
+	}
 )
 
 type BlacklistClient struct {
-	client dynamodbiface.DynamoDBAPI
-	table  string
+	client     dynamodbiface.DynamoDBAPI
+	table      string
+	listsTable string
+	retry      RetryPolicy
+}
+
+// DynamoConfig holds the connection settings of a BlacklistClient. Empty values
+// fall back to the AWS SDK defaults (shared config, environment, instance role).
+type DynamoConfig struct {
+	Table           string
+	Endpoint        string
+	Region          string
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string
+	// Table holding the named lists, Table followed by -lists when empty
+	ListsTable string
+	// Connection pool of the underlying HTTP client, shared by every request
+	MaxIdleConns    int
+	MaxConnsPerHost int
+	IdleConnTimeout time.Duration
+	// How unprocessed items of batch operations are retried, DefaultRetryPolicy when empty
+	Retry RetryPolicy
+}
+
+func newHttpClient(config *DynamoConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.MaxIdleConns > 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+		// The default of two idle connections per host would force almost every
+		// concurrent request to DynamoDB to open a new connection
+		transport.MaxIdleConnsPerHost = config.MaxIdleConns
+	}
+	if config.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = config.MaxConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+	return &http.Client{Transport: transport}
 }
 
-func newSession() (*session.Session, error) {
-	sess, err := session.NewSession()
-	svc := session.Must(sess, err)
-	return svc, err
+func newSession(config *DynamoConfig) (*session.Session, error) {
+	awsConfig := aws.NewConfig().WithHTTPClient(newHttpClient(config))
+	if config.Region != "" {
+		awsConfig = awsConfig.WithRegion(config.Region)
+	}
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint)
+	}
+	if config.AccessKeyId != "" {
+		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(config.AccessKeyId, config.SecretAccessKey, config.SessionToken))
+	}
+	return session.NewSessionWithOptions(session.Options{Config: *awsConfig})
 }
 
-func NewClient(table string) (*BlacklistClient, error) {
+// NewClient creates a BlacklistClient meant to be created once and shared, it is
+// safe for concurrent use.
+func NewClient(config *DynamoConfig) (*BlacklistClient, error) {
 	// Create AWS Session
-	sess, err := newSession()
+	sess, err := newSession(config)
 	if err != nil {
 		return nil, err
 	}
-	dynamoClient := &BlacklistClient{dynamodb.New(sess), table}
+	listsTable := config.ListsTable
+	if listsTable == "" {
+		listsTable = config.Table + "-lists"
+	}
+	dynamoClient := &BlacklistClient{dynamodb.New(sess), config.Table, listsTable, config.Retry.orDefault()}
 	return dynamoClient, nil
 }
 
 //Get
 
-func (receiver *BlacklistClient) GetRecordById(id *string) (*models.Record, error) {
+func (receiver *BlacklistClient) GetRecordById(ctx context.Context, id *string) (*models.Record, error) {
 	key := make(map[string]*dynamodb.AttributeValue)
 	key["id"] = &dynamodb.AttributeValue{S: id}
 	input := &dynamodb.GetItemInput{
 		TableName: &receiver.table,
 		Key:       key,
 	}
-	result, err := receiver.client.GetItem(input)
+	result, err := receiver.client.GetItemWithContext(ctx, input)
 	if err != nil {
-		return nil, err
+		return nil, dynamoError(err)
 	}
 	if result.Item == nil {
 		return nil, nil
@@ -51,30 +139,67 @@ func (receiver *BlacklistClient) GetRecordById(id *string) (*models.Record, erro
 	if err != nil {
 		return nil, err
 	}
+	if record.Expired(time.Now()) {
+		return nil, nil
+	}
 	return record, nil
 }
 
-func (receiver *BlacklistClient) GetRecordBatchByIds(ids []*string) ([]*models.Record, error) {
-	if len(ids) > 25 {
-		return nil, errors.New("ids list has more than BlacklistClient max batch (25)")
+func (receiver *BlacklistClient) GetRecordBatchByIds(ctx context.Context, ids []*string) ([]*models.Record, error) {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return nil, err
 	}
-	input := &dynamodb.BatchGetItemInput{
-		RequestItems: receiver.getBatchRequestFromIds(ids),
+	requestItems := receiver.getBatchRequestFromIds(ids)
+	dynamoRecords := make([]map[string]*dynamodb.AttributeValue, 0, len(ids))
+	var unprocessedErr error
+	for attempt := 1; ; attempt++ {
+		input := &dynamodb.BatchGetItemInput{
+			RequestItems: requestItems,
+		}
+		result, err := receiver.client.BatchGetItemWithContext(ctx, input)
+		if err != nil {
+			return nil, dynamoError(err)
+		}
+		dynamoRecords = append(dynamoRecords, result.Responses[receiver.table]...)
+		requestItems = result.UnprocessedKeys
+		if requestItems[receiver.table] == nil || len(requestItems[receiver.table].Keys) == 0 {
+			break
+		}
+		if attempt >= receiver.retry.MaxAttempts {
+			unprocessedErr = &UnprocessedError{Ids: keyIds(requestItems[receiver.table].Keys)}
+			break
+		}
+		err = receiver.retry.Wait(ctx, attempt)
+		if err != nil {
+			return nil, err
+		}
 	}
-	result, err := receiver.client.BatchGetItem(input)
+	records, err := receiver.parseDynamoRecords(dynamoRecords)
 	if err != nil {
 		return nil, err
 	}
-	dynamoRecords := result.Responses[receiver.table]
-	records, err := receiver.parseDynamoRecords(dynamoRecords)
+	return records, unprocessedErr
+}
+
+func keyIds(keys []map[string]*dynamodb.AttributeValue) []string {
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, *key["id"].S)
+	}
+	return ids
+}
+
+func (receiver *BlacklistClient) parseDynamoRecords(dynamoRecords []map[string]*dynamodb.AttributeValue) ([]*models.Record, error) {
+	records, err := receiver.parseDynamoItems(dynamoRecords)
 	if err != nil {
 		return nil, err
 	}
-	return records, nil
+	return withoutExpired(records), nil
 }
 
-func (receiver *BlacklistClient) parseDynamoRecords(dynamoRecords []map[string]*dynamodb.AttributeValue) ([]*models.Record, error) {
-	records := make([]*models.Record, 0, 25)
+// parseDynamoItems parses the items as stored, expired ones included.
+func (receiver *BlacklistClient) parseDynamoItems(dynamoRecords []map[string]*dynamodb.AttributeValue) ([]*models.Record, error) {
+	records := make([]*models.Record, 0, MaxBatchSize)
 	for _, dynamoRecord := range dynamoRecords {
 		record, err := models.FromDynamoItem(dynamoRecord)
 		if err != nil {
@@ -85,8 +210,20 @@ func (receiver *BlacklistClient) parseDynamoRecords(dynamoRecords []map[string]*
 	return records, nil
 }
 
+func withoutExpired(records []*models.Record) []*models.Record {
+	unexpired := make([]*models.Record, 0, len(records))
+	now := time.Now()
+	for _, record := range records {
+		// DynamoDB deletes expired items up to a few days after they expire
+		if !record.Expired(now) {
+			unexpired = append(unexpired, record)
+		}
+	}
+	return unexpired
+}
+
 func (receiver *BlacklistClient) getBatchRequestFromIds(ids []*string) map[string]*dynamodb.KeysAndAttributes {
-	items := make([]map[string]*dynamodb.AttributeValue, 0, 25)
+	items := make([]map[string]*dynamodb.AttributeValue, 0, MaxBatchSize)
 	for _, id := range ids {
 		item := make(map[string]*dynamodb.AttributeValue)
 		item["id"] = &dynamodb.AttributeValue{S: id}
@@ -100,42 +237,238 @@ func (receiver *BlacklistClient) getBatchRequestFromIds(ids []*string) map[strin
 	return requestItems
 }
 
-func (receiver *BlacklistClient) GetRecordsByQueries(queries []*models.Query, betweenQueries []*models.BetweenQuery, lastRecord map[string]*dynamodb.AttributeValue) ([]*models.Record, map[string]*dynamodb.AttributeValue, error) {
-	queryFilter, queriesInFilter := getFilterByQueries(queries)
-	betweenFilter, queriesInBetweenFilter := getFilterByBetweenQueries(betweenQueries)
-	var filter expression.ConditionBuilder
-	if queriesInFilter == 0 && queriesInBetweenFilter == 0 {
-		log.Print("As there are no queries a full scan will be performed")
+func (receiver *BlacklistClient) GetRecordsByQueries(ctx context.Context, queries []*models.Query, betweenQueries []*models.BetweenQuery, lastKey *string) ([]*models.Record, *string, error) {
+	records, nextKey, err := receiver.findRecords(ctx, queries, betweenQueries, lastKey)
+	if err != nil {
+		return nil, nil, err
 	}
-	if queriesInFilter > 0 && queriesInBetweenFilter == 0 {
-		filter = queryFilter
+	return withoutExpired(records), nextKey, nil
+}
+
+// findRecords returns a page of the items matching the queries, expired ones
+// included.
+func (receiver *BlacklistClient) findRecords(ctx context.Context, queries []*models.Query, betweenQueries []*models.BetweenQuery, lastKey *string) ([]*models.Record, *string, error) {
+	lastRecord, err := decodeLastKey(lastKey)
+	if err != nil {
+		return nil, nil, err
 	}
-	if queriesInFilter == 0 && queriesInBetweenFilter > 0 {
-		filter = betweenFilter
+	indexName, keyCondition, queries, betweenQueries := getKeyConditionByQueries(queries, betweenQueries)
+	var items []map[string]*dynamodb.AttributeValue
+	var keyQueries []*models.Query
+	var keyBetweenQueries []*models.BetweenQuery
+	if indexName != nil {
+		// DynamoDB rejects filters on the index keys, so those are applied once the page is read
+		keyQueries, queries, keyBetweenQueries, betweenQueries = splitKeyQueries(*indexName, queries, betweenQueries)
+		filter, hasFilter := getFilter(queries, betweenQueries)
+		items, lastRecord, err = receiver.queryIndex(ctx, indexName, keyCondition, filter, hasFilter, lastRecord)
+	} else {
+		filter, hasFilter := getFilter(queries, betweenQueries)
+		items, lastRecord, err = receiver.scan(ctx, filter, hasFilter, lastRecord)
 	}
-	if queriesInFilter > 0 && queriesInBetweenFilter > 0 {
-		filter = queryFilter.And(betweenFilter)
+	if err != nil {
+		return nil, nil, err
 	}
-	queryExpression, err := expression.NewBuilder().WithFilter(filter).Build()
+	records, err := receiver.parseDynamoItems(items)
 	if err != nil {
 		return nil, nil, err
 	}
-	input := &dynamodb.ScanInput{
+	if len(keyQueries) > 0 || len(keyBetweenQueries) > 0 {
+		matchingRecords := make([]*models.Record, 0, len(records))
+		for _, record := range records {
+			if models.MatchesAll(record, keyQueries, keyBetweenQueries) {
+				matchingRecords = append(matchingRecords, record)
+			}
+		}
+		records = matchingRecords
+	}
+	nextKey, err := encodeLastKey(lastRecord)
+	if err != nil {
+		return nil, nil, err
+	}
+	return records, nextKey, nil
+}
+
+func (receiver *BlacklistClient) queryIndex(ctx context.Context, indexName *string, keyCondition expression.KeyConditionBuilder, filter expression.ConditionBuilder, hasFilter bool, lastRecord map[string]*dynamodb.AttributeValue) ([]map[string]*dynamodb.AttributeValue, map[string]*dynamodb.AttributeValue, error) {
+	builder := expression.NewBuilder().WithKeyCondition(keyCondition)
+	if hasFilter {
+		builder = builder.WithFilter(filter)
+	}
+	queryExpression, err := builder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	input := &dynamodb.QueryInput{
 		ExpressionAttributeNames:  queryExpression.Names(),
 		ExpressionAttributeValues: queryExpression.Values(),
+		KeyConditionExpression:    queryExpression.KeyCondition(),
 		FilterExpression:          queryExpression.Filter(),
 		TableName:                 &receiver.table,
+		IndexName:                 indexName,
 		ExclusiveStartKey:         lastRecord,
 	}
-	result, err := receiver.client.Scan(input)
+	result, err := receiver.client.QueryWithContext(ctx, input)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, dynamoError(err)
+	}
+	return result.Items, result.LastEvaluatedKey, nil
+}
+
+func (receiver *BlacklistClient) scan(ctx context.Context, filter expression.ConditionBuilder, hasFilter bool, lastRecord map[string]*dynamodb.AttributeValue) ([]map[string]*dynamodb.AttributeValue, map[string]*dynamodb.AttributeValue, error) {
+	input := &dynamodb.ScanInput{
+		TableName:         &receiver.table,
+		ExclusiveStartKey: lastRecord,
 	}
-	records, err := receiver.parseDynamoRecords(result.Items)
+	if hasFilter {
+		log.Print("No index matches the given queries so a full scan will be performed")
+		queryExpression, err := expression.NewBuilder().WithFilter(filter).Build()
+		if err != nil {
+			return nil, nil, err
+		}
+		input.ExpressionAttributeNames = queryExpression.Names()
+		input.ExpressionAttributeValues = queryExpression.Values()
+		input.FilterExpression = queryExpression.Filter()
+	} else {
+		log.Print("As there are no queries a full scan will be performed")
+	}
+	result, err := receiver.client.ScanWithContext(ctx, input)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, dynamoError(err)
+	}
+	return result.Items, result.LastEvaluatedKey, nil
+}
+
+// getKeyConditionByQueries looks for an EQUALS query on an indexed field and,
+// when there is one, builds the key condition for that index together with the
+// first range condition on added_date. Queries used in the key condition are
+// removed from the returned ones, which must still be applied as a filter.
+func getKeyConditionByQueries(queries []*models.Query, betweenQueries []*models.BetweenQuery) (*string, expression.KeyConditionBuilder, []*models.Query, []*models.BetweenQuery) {
+	var keyCondition expression.KeyConditionBuilder
+	hashIndex := -1
+	for index, query := range queries {
+		if _, ok := queryIndexes[query.Field]; ok && query.Operand == "EQUALS" {
+			hashIndex = index
+			break
+		}
+	}
+	if hashIndex < 0 {
+		return nil, keyCondition, queries, betweenQueries
+	}
+	hashQuery := queries[hashIndex]
+	indexName := queryIndexes[hashQuery.Field]
+	keyCondition = expression.Key(hashQuery.Field).Equal(expression.Value(hashQuery.Value))
+	remainingQueries := make([]*models.Query, 0, len(queries))
+	remainingBetweenQueries := make([]*models.BetweenQuery, 0, len(betweenQueries))
+	hasRangeCondition := false
+	for _, betweenQuery := range betweenQueries {
+		if !hasRangeCondition && betweenQuery.Field == rangeKey {
+			keyCondition = keyCondition.And(expression.Key(rangeKey).Between(expression.Value(betweenQuery.Init), expression.Value(betweenQuery.End)))
+			hasRangeCondition = true
+			continue
+		}
+		remainingBetweenQueries = append(remainingBetweenQueries, betweenQuery)
+	}
+	for index, query := range queries {
+		if index == hashIndex {
+			continue
+		}
+		if !hasRangeCondition && query.Field == rangeKey {
+			rangeCondition, ok := getRangeKeyCondition(query)
+			if ok {
+				keyCondition = keyCondition.And(rangeCondition)
+				hasRangeCondition = true
+				continue
+			}
+		}
+		remainingQueries = append(remainingQueries, query)
+	}
+	return &indexName, keyCondition, remainingQueries, remainingBetweenQueries
+}
+
+func splitKeyQueries(indexName string, queries []*models.Query, betweenQueries []*models.BetweenQuery) ([]*models.Query, []*models.Query, []*models.BetweenQuery, []*models.BetweenQuery) {
+	isKey := func(field string) bool {
+		return field == rangeKey || queryIndexes[field] == indexName
+	}
+	keyQueries := make([]*models.Query, 0, len(queries))
+	filterQueries := make([]*models.Query, 0, len(queries))
+	for _, query := range queries {
+		if isKey(query.Field) {
+			keyQueries = append(keyQueries, query)
+		} else {
+			filterQueries = append(filterQueries, query)
+		}
+	}
+	keyBetweenQueries := make([]*models.BetweenQuery, 0, len(betweenQueries))
+	filterBetweenQueries := make([]*models.BetweenQuery, 0, len(betweenQueries))
+	for _, betweenQuery := range betweenQueries {
+		if isKey(betweenQuery.Field) {
+			keyBetweenQueries = append(keyBetweenQueries, betweenQuery)
+		} else {
+			filterBetweenQueries = append(filterBetweenQueries, betweenQuery)
+		}
+	}
+	return keyQueries, filterQueries, keyBetweenQueries, filterBetweenQueries
+}
+
+func getRangeKeyCondition(query *models.Query) (expression.KeyConditionBuilder, bool) {
+	switch query.Operand {
+	case "EQUALS":
+		return expression.Key(rangeKey).Equal(expression.Value(query.Value)), true
+	case "GREATER_THAN":
+		return expression.Key(rangeKey).GreaterThan(expression.Value(query.Value)), true
+	case "LESSER_THAN":
+		return expression.Key(rangeKey).LessThan(expression.Value(query.Value)), true
+	case "BEGINS_WITH":
+		return expression.Key(rangeKey).BeginsWith(query.Value), true
+	}
+	return expression.KeyConditionBuilder{}, false
+}
+
+func getFilter(queries []*models.Query, betweenQueries []*models.BetweenQuery) (expression.ConditionBuilder, bool) {
+	queryFilter, queriesInFilter := getFilterByQueries(queries)
+	betweenFilter, queriesInBetweenFilter := getFilterByBetweenQueries(betweenQueries)
+	if queriesInFilter > 0 && queriesInBetweenFilter > 0 {
+		return queryFilter.And(betweenFilter), true
+	}
+	if queriesInFilter > 0 {
+		return queryFilter, true
+	}
+	if queriesInBetweenFilter > 0 {
+		return betweenFilter, true
+	}
+	return queryFilter, false
+}
+
+func encodeLastKey(lastRecord map[string]*dynamodb.AttributeValue) (*string, error) {
+	if lastRecord == nil {
+		return nil, nil
+	}
+	key := make(map[string]string)
+	for name, value := range lastRecord {
+		key[name] = *value.S
 	}
-	return records, result.LastEvaluatedKey, nil
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	lastKey := string(encoded)
+	return &lastKey, nil
+}
+
+func decodeLastKey(lastKey *string) (map[string]*dynamodb.AttributeValue, error) {
+	if lastKey == nil {
+		return nil, nil
+	}
+	key := make(map[string]string)
+	err := json.Unmarshal([]byte(*lastKey), &key)
+	if err != nil {
+		return nil, err
+	}
+	lastRecord := make(map[string]*dynamodb.AttributeValue)
+	for name, value := range key {
+		value := value
+		lastRecord[name] = &dynamodb.AttributeValue{S: &value}
+	}
+	return lastRecord, nil
 }
 
 func getFilterByQueries(queries []*models.Query) (expression.ConditionBuilder, int) {
@@ -145,6 +478,10 @@ func getFilterByQueries(queries []*models.Query) (expression.ConditionBuilder, i
 		switch query.Operand {
 		case "EQUALS":
 			currentFilter = expression.Equal(expression.Name(query.Field), expression.Value(query.Value))
+			if query.Value == "" {
+				// empty values are left out of the items, see models.setStringAttribute
+				currentFilter = expression.AttributeNotExists(expression.Name(query.Field))
+			}
 		case "GREATER_THAN":
 			currentFilter = expression.GreaterThan(expression.Name(query.Field), expression.Value(query.Value))
 		case "LESSER_THAN":
@@ -176,39 +513,221 @@ func getFilterByBetweenQueries(queries []*models.BetweenQuery) (expression.Condi
 
 //Save
 
-func (receiver *BlacklistClient) SaveRecord(record *models.Record) (*models.Record, error) {
-	input := &dynamodb.PutItemInput{
-		TableName: &receiver.table,
-		Item:      record.ToDynamoItem(),
+// optionalAttributes are left out of an item when the record has no value for
+// them, writes replacing a whole item remove them.
+var optionalAttributes = []string{models.TtlAttribute, "reason_code", "note", "source", "created_by", "labels"}
+
+func (receiver *BlacklistClient) SaveRecord(ctx context.Context, record *models.Record, mode models.SaveMode, expectedVersion int64) (*models.Record, error) {
+	now := time.Now()
+	conditions := make([]expression.ConditionBuilder, 0, 2)
+	if expectedVersion != 0 {
+		conditions = append(conditions, hasVersion(expectedVersion, now))
+	}
+	switch mode {
+	case models.SaveCreate:
+		conditions = append(conditions, expression.AttributeNotExists(expression.Name(hashKey)).Or(isExpired(now)))
+	case models.SaveUpsert:
+		conditions = append(conditions, isExpired(now).Not())
+	}
+	saved, err := receiver.updateRecord(ctx, record, mode == models.SaveUpsert, conditions)
+	if !isConditionalCheckFailed(err) {
+		return saved, err
+	}
+	switch mode {
+	case models.SaveUpsert:
+		if expectedVersion == 0 {
+			// the stored item expired, it must not pass its data on to the new record
+			return receiver.updateRecord(ctx, record, false, nil)
+		}
+	case models.SaveCreate:
+		id := record.Id()
+		existing, err := receiver.GetRecordById(ctx, &id)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrAlreadyExists
+		}
+	}
+	return nil, ErrVersionConflict
+}
+
+// updateRecord writes the record with an UpdateItem so the version can be
+// incremented in place. When preserving, added_date and created_by are only set
+// if the stored item has none and missing attributes are left untouched,
+// otherwise the item is replaced as a whole.
+func (receiver *BlacklistClient) updateRecord(ctx context.Context, record *models.Record, preserve bool, conditions []expression.ConditionBuilder) (*models.Record, error) {
+	defaultExpiresAt := record.DefaultExpiresAt()
+	if !preserve {
+		record = record.WithDefaultExpiration()
+	}
+	item := record.ToDynamoItem()
+	var update expression.UpdateBuilder
+	for name, value := range item {
+		operand := expression.Value(attributeValue{value})
+		switch {
+		case name == hashKey || name == models.VersionAttribute:
+			continue
+		case preserve && (name == rangeKey || name == "created_by"):
+			update = update.Set(expression.Name(name), expression.Name(name).IfNotExists(operand))
+		default:
+			update = update.Set(expression.Name(name), operand)
+		}
+	}
+	_, hasExpiration := item[models.TtlAttribute]
+	if preserve && !hasExpiration && !defaultExpiresAt.IsZero() {
+		// the expiration of the stored item wins over the default one
+		operand := expression.Value(defaultExpiresAt.Unix())
+		update = update.Set(expression.Name(models.TtlAttribute), expression.Name(models.TtlAttribute).IfNotExists(operand))
+	}
+	if !preserve {
+		for _, name := range optionalAttributes {
+			if _, ok := item[name]; !ok {
+				update = update.Remove(expression.Name(name))
+			}
+		}
+	}
+	return receiver.updateItem(ctx, record.Id(), update, conditions)
+}
+
+// updateItem runs the update over the item with the given id incrementing its
+// version, as long as all conditions hold, and returns the updated record.
+func (receiver *BlacklistClient) updateItem(ctx context.Context, id string, update expression.UpdateBuilder, conditions []expression.ConditionBuilder) (*models.Record, error) {
+	update = update.Add(expression.Name(models.VersionAttribute), expression.Value(1))
+	builder := expression.NewBuilder().WithUpdate(update)
+	if len(conditions) > 0 {
+		condition := conditions[0]
+		for _, other := range conditions[1:] {
+			condition = condition.And(other)
+		}
+		builder = builder.WithCondition(condition)
 	}
-	_, err := receiver.client.PutItem(input)
+	expr, err := builder.Build()
 	if err != nil {
 		return nil, err
 	}
-	return record, nil
+	result, err := receiver.client.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 &receiver.table,
+		Key:                       map[string]*dynamodb.AttributeValue{hashKey: {S: &id}},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+	})
+	if err != nil {
+		return nil, dynamoError(err)
+	}
+	return models.FromDynamoItem(result.Attributes)
+}
+
+// isExpired holds for items whose TTL already passed but DynamoDB did not
+// delete yet.
+func isExpired(now time.Time) expression.ConditionBuilder {
+	return expression.Name(models.TtlAttribute).LessThanEqual(expression.Value(now.Unix()))
+}
+
+// hasVersion holds for live items with the given version.
+func hasVersion(version int64, now time.Time) expression.ConditionBuilder {
+	return expression.Name(models.VersionAttribute).Equal(expression.Value(version)).And(isExpired(now).Not())
+}
+
+func isConditionalCheckFailed(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
+}
+
+// dynamoError classifies the failures of DynamoDB calls into the store errors,
+// other errors are returned as they are.
+func dynamoError(err error) error {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return err
+	}
+	switch {
+	case awsErr.Code() == request.CanceledErrorCode:
+		return &storeError{context.Canceled, err}
+	case throttlingCodes[awsErr.Code()]:
+		return &storeError{ErrThrottled, err}
+	case unavailableCodes[awsErr.Code()] || request.IsErrorRetryable(err):
+		return &storeError{ErrUnavailable, err}
+	case invalidRequestCodes[awsErr.Code()]:
+		return &storeError{ErrInvalidRequest, err}
+	}
+	return err
 }
 
-func (receiver *BlacklistClient) SaveBatchRecords(records []*models.Record) ([]*models.Record, error) {
-	if len(records) > 25 {
-		return nil, errors.New("ids list has more than BlacklistClient max batch (25)")
+// attributeValue passes an already built attribute value to the expression
+// builder, which would otherwise marshal it as a map.
+type attributeValue struct {
+	value *dynamodb.AttributeValue
+}
+
+func (receiver attributeValue) MarshalDynamoDBAttributeValue(av *dynamodb.AttributeValue) error {
+	*av = *receiver.value
+	return nil
+}
+
+func (receiver *BlacklistClient) SaveBatchRecords(ctx context.Context, records []*models.Record) ([]*models.Record, error) {
+	if err := checkBatchSize(len(records)); err != nil {
+		return nil, err
 	}
-	input := &dynamodb.BatchWriteItemInput{
-		RequestItems: receiver.getWriteBatchRequestFromModel(records),
+	err := receiver.batchWrite(ctx, receiver.getWriteBatchRequestFromModel(records))
+	if unprocessedErr, ok := err.(*UnprocessedError); ok {
+		unprocessed := make(map[string]bool, len(unprocessedErr.Ids))
+		for _, id := range unprocessedErr.Ids {
+			unprocessed[id] = true
+		}
+		saved := make([]*models.Record, 0, len(records))
+		for _, record := range records {
+			if !unprocessed[record.Id()] {
+				saved = append(saved, record)
+			}
+		}
+		return saved, err
 	}
-	result, err := receiver.client.BatchWriteItem(input)
 	if err != nil {
 		return nil, err
 	}
-	for len(result.UnprocessedItems) != 0 {
-		input = &dynamodb.BatchWriteItemInput{
-			RequestItems: result.UnprocessedItems,
+	return records, nil
+}
+
+// batchWrite sends the write requests retrying the unprocessed ones with the
+// client's RetryPolicy, the ones left when the budget runs out are returned in
+// an UnprocessedError.
+func (receiver *BlacklistClient) batchWrite(ctx context.Context, requestItems map[string][]*dynamodb.WriteRequest) error {
+	for attempt := 1; ; attempt++ {
+		input := &dynamodb.BatchWriteItemInput{
+			RequestItems: requestItems,
 		}
-		result, err = receiver.client.BatchWriteItem(input)
+		result, err := receiver.client.BatchWriteItemWithContext(ctx, input)
 		if err != nil {
-			return nil, err
+			return dynamoError(err)
+		}
+		requestItems = result.UnprocessedItems
+		if len(requestItems[receiver.table]) == 0 {
+			return nil
+		}
+		if attempt >= receiver.retry.MaxAttempts {
+			return &UnprocessedError{Ids: writeRequestIds(requestItems[receiver.table])}
+		}
+		err = receiver.retry.Wait(ctx, attempt)
+		if err != nil {
+			return err
 		}
 	}
-	return records, nil
+}
+
+func writeRequestIds(requests []*dynamodb.WriteRequest) []string {
+	keys := make([]map[string]*dynamodb.AttributeValue, 0, len(requests))
+	for _, request := range requests {
+		if request.PutRequest != nil {
+			keys = append(keys, request.PutRequest.Item)
+		} else {
+			keys = append(keys, request.DeleteRequest.Key)
+		}
+	}
+	return keyIds(keys)
 }
 
 func (receiver *BlacklistClient) getWriteBatchRequestFromModel(records []*models.Record) map[string][]*dynamodb.WriteRequest {
@@ -221,41 +740,104 @@ func (receiver *BlacklistClient) getWriteBatchRequestFromModel(records []*models
 	return items
 }
 
+//Update
+
+// UpdateRecord sets or removes the updated attributes in place, the item has to
+// exist and not be expired.
+func (receiver *BlacklistClient) UpdateRecord(ctx context.Context, id *string, update *models.RecordUpdate, expectedVersion int64) (*models.Record, error) {
+	now := time.Now()
+	item := update.Values().ToDynamoItem()
+	var changes expression.UpdateBuilder
+	for _, field := range update.Fields {
+		if value, ok := item[field]; ok {
+			changes = changes.Set(expression.Name(field), expression.Value(attributeValue{value}))
+		} else {
+			changes = changes.Remove(expression.Name(field))
+		}
+	}
+	changes = changes.Set(expression.Name("updated_at"), expression.Value(attributeValue{item["updated_at"]}))
+	conditions := []expression.ConditionBuilder{expression.AttributeExists(expression.Name(hashKey)).And(isExpired(now).Not())}
+	if expectedVersion != 0 {
+		conditions = append(conditions, hasVersion(expectedVersion, now))
+	}
+	record, err := receiver.updateItem(ctx, *id, changes, conditions)
+	if !isConditionalCheckFailed(err) {
+		return record, err
+	}
+	if expectedVersion != 0 {
+		existing, err := receiver.GetRecordById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrVersionConflict
+		}
+	}
+	return nil, ErrNotFound
+}
+
 //Delete
 
-func (receiver *BlacklistClient) DeleteRecord(id *string) error {
+func (receiver *BlacklistClient) DeleteRecord(ctx context.Context, id *string, expectedVersion int64) error {
 	input := &dynamodb.DeleteItemInput{
 		TableName: &receiver.table,
 		Key:       map[string]*dynamodb.AttributeValue{"id": {S: id}},
 	}
-	_, err := receiver.client.DeleteItem(input)
+	if expectedVersion != 0 {
+		expr, err := expression.NewBuilder().WithCondition(hasVersion(expectedVersion, time.Now())).Build()
+		if err != nil {
+			return err
+		}
+		input.ConditionExpression = expr.Condition()
+		input.ExpressionAttributeNames = expr.Names()
+		input.ExpressionAttributeValues = expr.Values()
+	}
+	_, err := receiver.client.DeleteItemWithContext(ctx, input)
+	if isConditionalCheckFailed(err) {
+		return ErrVersionConflict
+	}
 	if err != nil {
-		return err
+		return dynamoError(err)
 	}
 	return nil
 }
 
-func (receiver *BlacklistClient) DeleteBatchRecords(ids []*string) error {
-	if len(ids) > 25 {
-		return errors.New("ids list has more than BlacklistClient max batch (25)")
-	}
-	input := &dynamodb.BatchWriteItemInput{
-		RequestItems: receiver.getDeleteBatchRequestFromIds(ids),
-	}
-	result, err := receiver.client.BatchWriteItem(input)
-	if err != nil {
+func (receiver *BlacklistClient) DeleteBatchRecords(ctx context.Context, ids []*string) error {
+	if err := checkBatchSize(len(ids)); err != nil {
 		return err
 	}
-	for len(result.UnprocessedItems) != 0 {
-		input = &dynamodb.BatchWriteItemInput{
-			RequestItems: result.UnprocessedItems,
-		}
-		result, err = receiver.client.BatchWriteItem(input)
+	return receiver.batchWrite(ctx, receiver.getDeleteBatchRequestFromIds(ids))
+}
+
+// DeleteRecordsByQueries deletes the matching items page by page, so expired
+// items are not left for DynamoDB to delete.
+func (receiver *BlacklistClient) DeleteRecordsByQueries(ctx context.Context, queries []*models.Query) error {
+	var lastKey *string
+	for {
+		records, nextKey, err := receiver.findRecords(ctx, queries, nil, lastKey)
 		if err != nil {
 			return err
 		}
+		for start := 0; start < len(records); start += MaxBatchSize {
+			end := start + MaxBatchSize
+			if end > len(records) {
+				end = len(records)
+			}
+			ids := make([]*string, 0, end-start)
+			for _, record := range records[start:end] {
+				id := record.StoredId()
+				ids = append(ids, &id)
+			}
+			err = receiver.batchWrite(ctx, receiver.getDeleteBatchRequestFromIds(ids))
+			if err != nil {
+				return err
+			}
+		}
+		if nextKey == nil {
+			return nil
+		}
+		lastKey = nextKey
 	}
-	return nil
 }
 
 func (receiver *BlacklistClient) getDeleteBatchRequestFromIds(ids []*string) map[string][]*dynamodb.WriteRequest {
@@ -267,3 +849,55 @@ func (receiver *BlacklistClient) getDeleteBatchRequestFromIds(ids []*string) map
 	items[receiver.table] = requests
 	return items
 }
+
+//Lists
+
+func (receiver *BlacklistClient) GetList(ctx context.Context, name string) (*models.List, error) {
+	result, err := receiver.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: &receiver.listsTable,
+		Key:       map[string]*dynamodb.AttributeValue{listsHashKey: {S: &name}},
+	})
+	if err != nil {
+		return nil, dynamoError(err)
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+	return models.FromDynamoListItem(result.Item)
+}
+
+func (receiver *BlacklistClient) CreateList(ctx context.Context, list *models.List) error {
+	expr, err := expression.NewBuilder().WithCondition(expression.AttributeNotExists(expression.Name(listsHashKey))).Build()
+	if err != nil {
+		return err
+	}
+	_, err = receiver.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 &receiver.listsTable,
+		Item:                      list.ToDynamoItem(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionalCheckFailed(err) {
+		return ErrListExists
+	}
+	return dynamoError(err)
+}
+
+func (receiver *BlacklistClient) DeleteList(ctx context.Context, name string) error {
+	expr, err := expression.NewBuilder().WithCondition(expression.AttributeExists(expression.Name(listsHashKey))).Build()
+	if err != nil {
+		return err
+	}
+	_, err = receiver.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName:                 &receiver.listsTable,
+		Key:                       map[string]*dynamodb.AttributeValue{listsHashKey: {S: &name}},
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionalCheckFailed(err) {
+		return ErrListNotFound
+	}
+	return dynamoError(err)
+}
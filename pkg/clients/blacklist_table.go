@@ -0,0 +1,189 @@
+package clients
+
+import (
+	"blacklist/models"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"log"
+)
+
+const hashKey = "id"
+
+// listsHashKey keys the items of the lists table by the name of the list
+const listsHashKey = "name"
+
+var (
+	tableNotFound       = "table %s does not exist"
+	keySchemaMismatch   = "table %s key schema does not match: expected hash key %s (S) and no range key"
+	indexMissing        = "table %s is missing global secondary index %s"
+	indexSchemaMismatch = "table %s index %s key schema does not match: expected hash key %s (S) and range key %s (S)"
+	indexProjection     = "table %s index %s must project ALL attributes"
+)
+
+// EnsureTable creates the table with its indexes and TTL when it does not
+// exist, otherwise it verifies the existing one. The lists table is created
+// when missing either way.
+func (receiver *BlacklistClient) EnsureTable(ctx context.Context) error {
+	_, err := receiver.describeTable(ctx, receiver.table)
+	if err == nil {
+		err = receiver.ensureListsTable(ctx)
+		if err != nil {
+			return err
+		}
+		return receiver.VerifyTable(ctx)
+	}
+	if !isResourceNotFound(err) {
+		return err
+	}
+	return receiver.CreateTable(ctx)
+}
+
+func isResourceNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == dynamodb.ErrCodeResourceNotFoundException
+}
+
+func (receiver *BlacklistClient) CreateTable(ctx context.Context) error {
+	attributes := []*dynamodb.AttributeDefinition{
+		{AttributeName: aws.String(hashKey), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+		{AttributeName: aws.String(rangeKey), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+	}
+	indexes := make([]*dynamodb.GlobalSecondaryIndex, 0, len(queryIndexes))
+	for field, indexName := range queryIndexes {
+		attributes = append(attributes, &dynamodb.AttributeDefinition{AttributeName: aws.String(field), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)})
+		indexes = append(indexes, &dynamodb.GlobalSecondaryIndex{
+			IndexName: aws.String(indexName),
+			KeySchema: []*dynamodb.KeySchemaElement{
+				{AttributeName: aws.String(field), KeyType: aws.String(dynamodb.KeyTypeHash)},
+				{AttributeName: aws.String(rangeKey), KeyType: aws.String(dynamodb.KeyTypeRange)},
+			},
+			Projection: &dynamodb.Projection{ProjectionType: aws.String(dynamodb.ProjectionTypeAll)},
+		})
+	}
+	log.Printf("Creating table %s", receiver.table)
+	_, err := receiver.client.CreateTableWithContext(ctx, &dynamodb.CreateTableInput{
+		TableName:              &receiver.table,
+		AttributeDefinitions:   attributes,
+		KeySchema:              []*dynamodb.KeySchemaElement{{AttributeName: aws.String(hashKey), KeyType: aws.String(dynamodb.KeyTypeHash)}},
+		GlobalSecondaryIndexes: indexes,
+		BillingMode:            aws.String(dynamodb.BillingModePayPerRequest),
+	})
+	if err != nil {
+		return err
+	}
+	err = receiver.client.WaitUntilTableExistsWithContext(ctx, &dynamodb.DescribeTableInput{TableName: &receiver.table})
+	if err != nil {
+		return err
+	}
+	_, err = receiver.client.UpdateTimeToLiveWithContext(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: &receiver.table,
+		TimeToLiveSpecification: &dynamodb.TimeToLiveSpecification{
+			AttributeName: aws.String(models.TtlAttribute),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return receiver.ensureListsTable(ctx)
+}
+
+func (receiver *BlacklistClient) ensureListsTable(ctx context.Context) error {
+	_, err := receiver.describeTable(ctx, receiver.listsTable)
+	if err == nil || !isResourceNotFound(err) {
+		return err
+	}
+	log.Printf("Creating table %s", receiver.listsTable)
+	_, err = receiver.client.CreateTableWithContext(ctx, &dynamodb.CreateTableInput{
+		TableName:            &receiver.listsTable,
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{{AttributeName: aws.String(listsHashKey), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)}},
+		KeySchema:            []*dynamodb.KeySchemaElement{{AttributeName: aws.String(listsHashKey), KeyType: aws.String(dynamodb.KeyTypeHash)}},
+		BillingMode:          aws.String(dynamodb.BillingModePayPerRequest),
+	})
+	if err != nil {
+		return err
+	}
+	return receiver.client.WaitUntilTableExistsWithContext(ctx, &dynamodb.DescribeTableInput{TableName: &receiver.listsTable})
+}
+
+func (receiver *BlacklistClient) VerifyTable(ctx context.Context) error {
+	table, err := receiver.describeTable(ctx, receiver.table)
+	if err != nil {
+		if isResourceNotFound(err) {
+			return errors.New(fmt.Sprintf(tableNotFound, receiver.table))
+		}
+		return err
+	}
+	attributeTypes := make(map[string]string)
+	for _, attribute := range table.AttributeDefinitions {
+		attributeTypes[*attribute.AttributeName] = *attribute.AttributeType
+	}
+	if !matchesKeySchema(table.KeySchema, attributeTypes, hashKey, "") {
+		return errors.New(fmt.Sprintf(keySchemaMismatch, receiver.table, hashKey))
+	}
+	for field, indexName := range queryIndexes {
+		var index *dynamodb.GlobalSecondaryIndexDescription
+		for _, tableIndex := range table.GlobalSecondaryIndexes {
+			if *tableIndex.IndexName == indexName {
+				index = tableIndex
+			}
+		}
+		if index == nil {
+			return errors.New(fmt.Sprintf(indexMissing, receiver.table, indexName))
+		}
+		if !matchesKeySchema(index.KeySchema, attributeTypes, field, rangeKey) {
+			return errors.New(fmt.Sprintf(indexSchemaMismatch, receiver.table, indexName, field, rangeKey))
+		}
+		if index.Projection == nil || aws.StringValue(index.Projection.ProjectionType) != dynamodb.ProjectionTypeAll {
+			return errors.New(fmt.Sprintf(indexProjection, receiver.table, indexName))
+		}
+	}
+	return receiver.verifyListsTable(ctx)
+}
+
+func (receiver *BlacklistClient) verifyListsTable(ctx context.Context) error {
+	table, err := receiver.describeTable(ctx, receiver.listsTable)
+	if err != nil {
+		if isResourceNotFound(err) {
+			return errors.New(fmt.Sprintf(tableNotFound, receiver.listsTable))
+		}
+		return err
+	}
+	attributeTypes := make(map[string]string)
+	for _, attribute := range table.AttributeDefinitions {
+		attributeTypes[*attribute.AttributeName] = *attribute.AttributeType
+	}
+	if !matchesKeySchema(table.KeySchema, attributeTypes, listsHashKey, "") {
+		return errors.New(fmt.Sprintf(keySchemaMismatch, receiver.listsTable, listsHashKey))
+	}
+	return nil
+}
+
+func (receiver *BlacklistClient) describeTable(ctx context.Context, name string) (*dynamodb.TableDescription, error) {
+	result, err := receiver.client.DescribeTableWithContext(ctx, &dynamodb.DescribeTableInput{TableName: &name})
+	if err != nil {
+		return nil, err
+	}
+	return result.Table, nil
+}
+
+func matchesKeySchema(keySchema []*dynamodb.KeySchemaElement, attributeTypes map[string]string, hash, rangeName string) bool {
+	expected := map[string]string{hash: dynamodb.KeyTypeHash}
+	if rangeName != "" {
+		expected[rangeName] = dynamodb.KeyTypeRange
+	}
+	if len(keySchema) != len(expected) {
+		return false
+	}
+	for _, key := range keySchema {
+		keyType, ok := expected[*key.AttributeName]
+		if !ok || keyType != *key.KeyType || attributeTypes[*key.AttributeName] != dynamodb.ScalarAttributeTypeS {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,466 @@
+package clients
+
+import (
+	"blacklist/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	bolt "go.etcd.io/bbolt"
+	"time"
+)
+
+const boltPageSize = 100
+
+var (
+	recordsBucket = []byte("records")
+	listsBucket   = []byte("lists")
+	indexedFields = []string{"client_id", "product_id", "added_date"}
+	indexSplitter = []byte{0}
+)
+
+type BoltClient struct {
+	db *bolt.DB
+}
+
+// boltScan describes the cursor walk used to answer a query: which bucket is
+// iterated, where the walk starts and while which keys it keeps going.
+type boltScan struct {
+	bucket  []byte
+	start   []byte
+	inRange func(key []byte) bool
+	index   bool
+}
+
+func NewBoltClient(path string) (*BoltClient, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if errors.Is(err, bolt.ErrTimeout) {
+		// another process holds the file until it closes it
+		return nil, &storeError{ErrUnavailable, err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(recordsBucket)
+		if err != nil {
+			return err
+		}
+		_, err = tx.CreateBucketIfNotExists(listsBucket)
+		if err != nil {
+			return err
+		}
+		for _, field := range indexedFields {
+			_, err = tx.CreateBucketIfNotExists([]byte(field))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &BoltClient{db}, nil
+}
+
+func (receiver *BoltClient) Close() error {
+	return receiver.db.Close()
+}
+
+// view and update refuse to start a transaction for an already finished
+// request, bolt transactions themselves can not be interrupted.
+func (receiver *BoltClient) view(ctx context.Context, operation func(tx *bolt.Tx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return receiver.db.View(operation)
+}
+
+func (receiver *BoltClient) update(ctx context.Context, operation func(tx *bolt.Tx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return receiver.db.Update(operation)
+}
+
+func indexKey(value, id string) []byte {
+	return []byte(value + string(indexSplitter) + id)
+}
+
+func splitIndexKey(key []byte) (string, string) {
+	index := bytes.Index(key, indexSplitter)
+	return string(key[:index]), string(key[index+1:])
+}
+
+func isIndexed(field string) bool {
+	for _, indexedField := range indexedFields {
+		if indexedField == field {
+			return true
+		}
+	}
+	return false
+}
+
+//Get
+
+func (receiver *BoltClient) GetRecordById(ctx context.Context, id *string) (*models.Record, error) {
+	var record *models.Record
+	err := receiver.view(ctx, func(tx *bolt.Tx) error {
+		var err error
+		record, err = getBoltRecord(tx.Bucket(recordsBucket), *id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if record != nil && record.Expired(time.Now()) {
+		return nil, nil
+	}
+	return record, nil
+}
+
+func (receiver *BoltClient) GetRecordBatchByIds(ctx context.Context, ids []*string) ([]*models.Record, error) {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return nil, err
+	}
+	records := make([]*models.Record, 0, len(ids))
+	err := receiver.view(ctx, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(recordsBucket)
+		now := time.Now()
+		for _, id := range ids {
+			record, err := getBoltRecord(bucket, *id)
+			if err != nil {
+				return err
+			}
+			if record != nil && !record.Expired(now) {
+				records = append(records, record)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func getBoltRecord(bucket *bolt.Bucket, id string) (*models.Record, error) {
+	data := bucket.Get([]byte(id))
+	if data == nil {
+		return nil, nil
+	}
+	record := &models.Record{}
+	err := json.Unmarshal(data, record)
+	if err != nil {
+		return nil, err
+	}
+	return record.WithStoredId(id), nil
+}
+
+func (receiver *BoltClient) GetRecordsByQueries(ctx context.Context, queries []*models.Query, betweenQueries []*models.BetweenQuery, lastKey *string) ([]*models.Record, *string, error) {
+	scan := planBoltScan(queries, betweenQueries)
+	records := make([]*models.Record, 0, boltPageSize)
+	now := time.Now()
+	var nextKey *string
+	err := receiver.view(ctx, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(recordsBucket)
+		cursor := tx.Bucket(scan.bucket).Cursor()
+		var key []byte
+		if lastKey == nil {
+			key, _ = cursor.Seek(scan.start)
+		} else {
+			key, _ = cursor.Seek([]byte(*lastKey))
+			if key != nil && string(key) == *lastKey {
+				key, _ = cursor.Next()
+			}
+		}
+		var previousKey string
+		for visited := 0; key != nil && scan.inRange(key); key, _ = cursor.Next() {
+			if visited == boltPageSize {
+				nextKey = &previousKey
+				return nil
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			id := string(key)
+			if scan.index {
+				_, id = splitIndexKey(key)
+			}
+			record, err := getBoltRecord(bucket, id)
+			if err != nil {
+				return err
+			}
+			if record != nil && !record.Expired(now) && models.MatchesAll(record, queries, betweenQueries) {
+				records = append(records, record)
+			}
+			previousKey = string(key)
+			visited++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return records, nextKey, nil
+}
+
+func planBoltScan(queries []*models.Query, betweenQueries []*models.BetweenQuery) *boltScan {
+	for _, query := range queries {
+		if isIndexed(query.Field) && query.Operand == "EQUALS" {
+			prefix := indexKey(query.Value, "")
+			return &boltScan{bucket: []byte(query.Field), start: prefix, index: true, inRange: func(key []byte) bool {
+				return bytes.HasPrefix(key, prefix)
+			}}
+		}
+	}
+	for _, query := range queries {
+		if isIndexed(query.Field) && query.Operand == "BEGINS_WITH" {
+			prefix := []byte(query.Value)
+			return &boltScan{bucket: []byte(query.Field), start: prefix, index: true, inRange: func(key []byte) bool {
+				return bytes.HasPrefix(key, prefix)
+			}}
+		}
+	}
+	for _, betweenQuery := range betweenQueries {
+		if isIndexed(betweenQuery.Field) {
+			end := betweenQuery.End
+			return &boltScan{bucket: []byte(betweenQuery.Field), start: []byte(betweenQuery.Init), index: true, inRange: func(key []byte) bool {
+				value, _ := splitIndexKey(key)
+				return value <= end
+			}}
+		}
+	}
+	for _, query := range queries {
+		if isIndexed(query.Field) && query.Operand == "GREATER_THAN" {
+			return &boltScan{bucket: []byte(query.Field), start: indexKey(query.Value, "\xff"), index: true, inRange: func(key []byte) bool {
+				return true
+			}}
+		}
+		if isIndexed(query.Field) && query.Operand == "LESSER_THAN" {
+			limit := query.Value
+			return &boltScan{bucket: []byte(query.Field), index: true, inRange: func(key []byte) bool {
+				value, _ := splitIndexKey(key)
+				return value < limit
+			}}
+		}
+	}
+	return &boltScan{bucket: recordsBucket, inRange: func(key []byte) bool {
+		return true
+	}}
+}
+
+//Save
+
+func (receiver *BoltClient) SaveRecord(ctx context.Context, record *models.Record, mode models.SaveMode, expectedVersion int64) (*models.Record, error) {
+	err := receiver.update(ctx, func(tx *bolt.Tx) error {
+		stored, err := getBoltRecord(tx.Bucket(recordsBucket), record.Id())
+		if err != nil {
+			return err
+		}
+		record, err = resolveSave(stored, record, mode, expectedVersion, time.Now())
+		if err != nil {
+			return err
+		}
+		return putBoltRecord(tx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (receiver *BoltClient) SaveBatchRecords(ctx context.Context, records []*models.Record) ([]*models.Record, error) {
+	if err := checkBatchSize(len(records)); err != nil {
+		return nil, err
+	}
+	err := receiver.update(ctx, func(tx *bolt.Tx) error {
+		for _, record := range records {
+			err := putBoltRecord(tx, record)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func putBoltRecord(tx *bolt.Tx, record *models.Record) error {
+	id := record.Id()
+	err := deleteBoltRecord(tx, id)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	err = tx.Bucket(recordsBucket).Put([]byte(id), data)
+	if err != nil {
+		return err
+	}
+	for _, field := range indexedFields {
+		err = tx.Bucket([]byte(field)).Put(indexKey(record.Value(field), id), []byte{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Update
+
+func (receiver *BoltClient) UpdateRecord(ctx context.Context, id *string, update *models.RecordUpdate, expectedVersion int64) (*models.Record, error) {
+	var record *models.Record
+	err := receiver.update(ctx, func(tx *bolt.Tx) error {
+		stored, err := getBoltRecord(tx.Bucket(recordsBucket), *id)
+		if err != nil {
+			return err
+		}
+		record, err = resolveUpdate(stored, update, expectedVersion, time.Now())
+		if err != nil {
+			return err
+		}
+		return putBoltRecord(tx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+//Delete
+
+func (receiver *BoltClient) DeleteRecord(ctx context.Context, id *string, expectedVersion int64) error {
+	return receiver.update(ctx, func(tx *bolt.Tx) error {
+		if expectedVersion != 0 {
+			stored, err := getBoltRecord(tx.Bucket(recordsBucket), *id)
+			if err != nil {
+				return err
+			}
+			err = checkVersion(stored, expectedVersion, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+		return deleteBoltRecord(tx, *id)
+	})
+}
+
+func (receiver *BoltClient) DeleteBatchRecords(ctx context.Context, ids []*string) error {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return err
+	}
+	return receiver.update(ctx, func(tx *bolt.Tx) error {
+		for _, id := range ids {
+			err := deleteBoltRecord(tx, *id)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (receiver *BoltClient) DeleteRecordsByQueries(ctx context.Context, queries []*models.Query) error {
+	scan := planBoltScan(queries, nil)
+	return receiver.update(ctx, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(recordsBucket)
+		cursor := tx.Bucket(scan.bucket).Cursor()
+		// the cursor is moved by deleting, so the ids are collected first
+		var ids []string
+		for key, _ := cursor.Seek(scan.start); key != nil && scan.inRange(key); key, _ = cursor.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			id := string(key)
+			if scan.index {
+				_, id = splitIndexKey(key)
+			}
+			record, err := getBoltRecord(bucket, id)
+			if err != nil {
+				return err
+			}
+			if record != nil && models.MatchesAll(record, queries, nil) {
+				ids = append(ids, id)
+			}
+		}
+		for _, id := range ids {
+			err := deleteBoltRecord(tx, id)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func deleteBoltRecord(tx *bolt.Tx, id string) error {
+	bucket := tx.Bucket(recordsBucket)
+	data := bucket.Get([]byte(id))
+	if data == nil {
+		return nil
+	}
+	// index keys are built from the values as they were stored, which may be in
+	// a format older than the one the record is parsed to
+	var stored map[string]interface{}
+	err := json.Unmarshal(data, &stored)
+	if err != nil {
+		return err
+	}
+	for _, field := range indexedFields {
+		value, _ := stored[field].(string)
+		err = tx.Bucket([]byte(field)).Delete(indexKey(value, id))
+		if err != nil {
+			return err
+		}
+	}
+	return bucket.Delete([]byte(id))
+}
+
+//Lists
+
+func (receiver *BoltClient) GetList(ctx context.Context, name string) (*models.List, error) {
+	var list *models.List
+	err := receiver.view(ctx, func(tx *bolt.Tx) error {
+		data := tx.Bucket(listsBucket).Get([]byte(name))
+		if data == nil {
+			return nil
+		}
+		list = &models.List{}
+		return json.Unmarshal(data, list)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (receiver *BoltClient) CreateList(ctx context.Context, list *models.List) error {
+	return receiver.update(ctx, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(listsBucket)
+		if bucket.Get([]byte(list.Name())) != nil {
+			return ErrListExists
+		}
+		data, err := json.Marshal(list)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(list.Name()), data)
+	})
+}
+
+func (receiver *BoltClient) DeleteList(ctx context.Context, name string) error {
+	return receiver.update(ctx, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(listsBucket)
+		if bucket.Get([]byte(name)) == nil {
+			return ErrListNotFound
+		}
+		return bucket.Delete([]byte(name))
+	})
+}
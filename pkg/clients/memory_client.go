@@ -0,0 +1,212 @@
+package clients
+
+import (
+	"blacklist/models"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const memoryPageSize = 100
+
+type MemoryClient struct {
+	mu      sync.RWMutex
+	records map[string]*models.Record
+	lists   map[string]*models.List
+}
+
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{records: make(map[string]*models.Record), lists: make(map[string]*models.List)}
+}
+
+//Get
+
+func (receiver *MemoryClient) GetRecordById(ctx context.Context, id *string) (*models.Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	receiver.mu.RLock()
+	defer receiver.mu.RUnlock()
+	record, ok := receiver.records[*id]
+	if !ok || record.Expired(time.Now()) {
+		return nil, nil
+	}
+	return record, nil
+}
+
+func (receiver *MemoryClient) GetRecordBatchByIds(ctx context.Context, ids []*string) ([]*models.Record, error) {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	receiver.mu.RLock()
+	defer receiver.mu.RUnlock()
+	records := make([]*models.Record, 0, len(ids))
+	now := time.Now()
+	for _, id := range ids {
+		if record, ok := receiver.records[*id]; ok && !record.Expired(now) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (receiver *MemoryClient) GetRecordsByQueries(ctx context.Context, queries []*models.Query, betweenQueries []*models.BetweenQuery, lastKey *string) ([]*models.Record, *string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	receiver.mu.RLock()
+	defer receiver.mu.RUnlock()
+	ids := make([]string, 0, len(receiver.records))
+	for id := range receiver.records {
+		if lastKey == nil || id > *lastKey {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	records := make([]*models.Record, 0, memoryPageSize)
+	now := time.Now()
+	for index, id := range ids {
+		if index == memoryPageSize {
+			nextKey := ids[index-1]
+			return records, &nextKey, nil
+		}
+		record := receiver.records[id]
+		if !record.Expired(now) && models.MatchesAll(record, queries, betweenQueries) {
+			records = append(records, record)
+		}
+	}
+	return records, nil, nil
+}
+
+//Save
+
+func (receiver *MemoryClient) SaveRecord(ctx context.Context, record *models.Record, mode models.SaveMode, expectedVersion int64) (*models.Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	record, err := resolveSave(receiver.records[record.Id()], record, mode, expectedVersion, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	receiver.records[record.Id()] = record
+	return record, nil
+}
+
+func (receiver *MemoryClient) SaveBatchRecords(ctx context.Context, records []*models.Record) ([]*models.Record, error) {
+	if err := checkBatchSize(len(records)); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	for _, record := range records {
+		receiver.records[record.Id()] = record
+	}
+	return records, nil
+}
+
+//Update
+
+func (receiver *MemoryClient) UpdateRecord(ctx context.Context, id *string, update *models.RecordUpdate, expectedVersion int64) (*models.Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	record, err := resolveUpdate(receiver.records[*id], update, expectedVersion, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	receiver.records[*id] = record
+	return record, nil
+}
+
+//Delete
+
+func (receiver *MemoryClient) DeleteRecord(ctx context.Context, id *string, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	err := checkVersion(receiver.records[*id], expectedVersion, time.Now())
+	if err != nil {
+		return err
+	}
+	delete(receiver.records, *id)
+	return nil
+}
+
+func (receiver *MemoryClient) DeleteBatchRecords(ctx context.Context, ids []*string) error {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	for _, id := range ids {
+		delete(receiver.records, *id)
+	}
+	return nil
+}
+
+func (receiver *MemoryClient) DeleteRecordsByQueries(ctx context.Context, queries []*models.Query) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	for id, record := range receiver.records {
+		if models.MatchesAll(record, queries, nil) {
+			delete(receiver.records, id)
+		}
+	}
+	return nil
+}
+
+//Lists
+
+func (receiver *MemoryClient) GetList(ctx context.Context, name string) (*models.List, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	receiver.mu.RLock()
+	defer receiver.mu.RUnlock()
+	return receiver.lists[name], nil
+}
+
+func (receiver *MemoryClient) CreateList(ctx context.Context, list *models.List) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	if _, ok := receiver.lists[list.Name()]; ok {
+		return ErrListExists
+	}
+	receiver.lists[list.Name()] = list
+	return nil
+}
+
+func (receiver *MemoryClient) DeleteList(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	if _, ok := receiver.lists[name]; !ok {
+		return ErrListNotFound
+	}
+	delete(receiver.lists, name)
+	return nil
+}
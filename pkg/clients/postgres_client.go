@@ -0,0 +1,494 @@
+package clients
+
+import (
+	"blacklist/models"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const postgresPageSize = 100
+
+var (
+	tableNamePattern = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+	postgresColumns  = map[string]string{
+		"record_id":   "record_id",
+		"client_id":   "client_id",
+		"product_id":  "product_id",
+		"added_date":  "added_date",
+		"reason_code": "reason_code",
+		"source":      "source",
+		"list":        "list",
+		"tenant":      "tenant",
+		"kind":        "kind",
+	}
+	postgresOperators = map[string]string{
+		"EQUALS":       "=",
+		"GREATER_THAN": ">",
+		"LESSER_THAN":  "<",
+		"BEGINS_WITH":  "LIKE",
+	}
+	// Every entry is applied once, in order, and recorded in the {table}_migrations table.
+	postgresMigrations = []string{
+		`CREATE TABLE "{table}" (
+			id text COLLATE "C" PRIMARY KEY,
+			record_id text COLLATE "C" NOT NULL,
+			client_id text COLLATE "C" NOT NULL,
+			product_id text COLLATE "C" NOT NULL,
+			added_date text COLLATE "C" NOT NULL
+		);
+		CREATE INDEX "{table}_client_id_idx" ON "{table}" (client_id, added_date);
+		CREATE INDEX "{table}_product_id_idx" ON "{table}" (product_id, added_date);
+		CREATE INDEX "{table}_added_date_idx" ON "{table}" (added_date);`,
+		`ALTER TABLE "{table}" ADD COLUMN expires_at timestamptz;`,
+		`ALTER TABLE "{table}"
+			ADD COLUMN reason_code text COLLATE "C" NOT NULL DEFAULT '',
+			ADD COLUMN note text NOT NULL DEFAULT '',
+			ADD COLUMN source text COLLATE "C" NOT NULL DEFAULT '',
+			ADD COLUMN created_by text NOT NULL DEFAULT '',
+			ADD COLUMN labels jsonb;
+		CREATE INDEX "{table}_reason_code_idx" ON "{table}" (reason_code);
+		CREATE INDEX "{table}_source_idx" ON "{table}" (source);`,
+		`ALTER TABLE "{table}" ADD COLUMN updated_at text COLLATE "C";`,
+		`ALTER TABLE "{table}" ADD COLUMN version bigint NOT NULL DEFAULT 0;`,
+		`ALTER TABLE "{table}" ADD COLUMN kind text NOT NULL DEFAULT '';`,
+		`ALTER TABLE "{table}" ADD COLUMN list text COLLATE "C" NOT NULL DEFAULT '';
+		CREATE INDEX "{table}_list_idx" ON "{table}" (list);
+		CREATE TABLE "{table}_lists" (
+			name text COLLATE "C" PRIMARY KEY,
+			default_ttl_seconds bigint NOT NULL DEFAULT 0,
+			max_batch_size integer NOT NULL DEFAULT 0,
+			created_at timestamptz NOT NULL
+		);`,
+		`ALTER TABLE "{table}" ADD COLUMN tenant text COLLATE "C" NOT NULL DEFAULT '';
+		CREATE INDEX "{table}_tenant_idx" ON "{table}" (tenant, list);`,
+	}
+	// Expired rows are kept until they are saved again or deleted, reads skip them
+	notExpired = "(expires_at IS NULL OR expires_at > now())"
+	// Postgres error classes of the failures worth retrying or caused by the request
+	postgresErrorClasses = map[pq.ErrorClass]error{
+		"08": ErrUnavailable,
+		"53": ErrThrottled,
+		"22": ErrInvalidRequest,
+	}
+)
+
+// postgresQuerier is satisfied by both *sql.DB and *sql.Tx.
+type postgresQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+type PostgresClient struct {
+	db    *sql.DB
+	table string
+}
+
+func NewPostgresClient(dsn, table string) (*PostgresClient, error) {
+	if !tableNamePattern.MatchString(table) {
+		return nil, errors.New(fmt.Sprintf("invalid postgres table name %q", table))
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	client := &PostgresClient{db, table}
+	err = client.migrate()
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+func (receiver *PostgresClient) Close() error {
+	return receiver.db.Close()
+}
+
+func (receiver *PostgresClient) sql(statement string) string {
+	return strings.ReplaceAll(statement, "{table}", receiver.table)
+}
+
+func (receiver *PostgresClient) migrate() error {
+	_, err := receiver.db.Exec(receiver.sql(`CREATE TABLE IF NOT EXISTS "{table}_migrations" (version integer PRIMARY KEY)`))
+	if err != nil {
+		return err
+	}
+	tx, err := receiver.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(receiver.sql(`LOCK TABLE "{table}_migrations" IN EXCLUSIVE MODE`))
+	if err != nil {
+		return err
+	}
+	var version int
+	err = tx.QueryRow(receiver.sql(`SELECT coalesce(max(version), 0) FROM "{table}_migrations"`)).Scan(&version)
+	if err != nil {
+		return err
+	}
+	for ; version < len(postgresMigrations); version++ {
+		_, err = tx.Exec(receiver.sql(postgresMigrations[version]))
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(receiver.sql(`INSERT INTO "{table}_migrations" (version) VALUES ($1)`), version+1)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+//Get
+
+func (receiver *PostgresClient) GetRecordById(ctx context.Context, id *string) (*models.Record, error) {
+	records, err := receiver.queryRecords(ctx, receiver.db, receiver.sql(`SELECT to_json(r) FROM "{table}" r WHERE id = $1 AND `+notExpired), *id)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return records[0], nil
+}
+
+func (receiver *PostgresClient) GetRecordBatchByIds(ctx context.Context, ids []*string) ([]*models.Record, error) {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return nil, err
+	}
+	return receiver.queryRecords(ctx, receiver.db, receiver.sql(`SELECT to_json(r) FROM "{table}" r WHERE id = ANY($1) AND `+notExpired), pq.Array(dereference(ids)))
+}
+
+func (receiver *PostgresClient) GetRecordsByQueries(ctx context.Context, queries []*models.Query, betweenQueries []*models.BetweenQuery, lastKey *string) ([]*models.Record, *string, error) {
+	conditions, args, err := postgresConditions(queries, betweenQueries)
+	if err != nil {
+		return nil, nil, err
+	}
+	conditions = append(conditions, notExpired)
+	if lastKey != nil {
+		args = append(args, *lastKey)
+		conditions = append(conditions, fmt.Sprintf("id > $%d", len(args)))
+	}
+	statement := `SELECT to_json(r) FROM "{table}" r WHERE ` + strings.Join(conditions, " AND ")
+	statement += fmt.Sprintf(" ORDER BY id LIMIT %d", postgresPageSize)
+	records, err := receiver.queryRecords(ctx, receiver.db, receiver.sql(statement), args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) < postgresPageSize {
+		return records, nil, nil
+	}
+	// rows written with an older key format sort by the id they are stored under
+	nextKey := records[len(records)-1].StoredId()
+	return records, &nextKey, nil
+}
+
+// postgresConditions returns the conditions matching the queries together with
+// the arguments they take.
+func postgresConditions(queries []*models.Query, betweenQueries []*models.BetweenQuery) ([]string, []interface{}, error) {
+	conditions := make([]string, 0, len(queries)+len(betweenQueries)+2)
+	args := make([]interface{}, 0, len(queries)+2*len(betweenQueries)+1)
+	for _, query := range queries {
+		column, ok := postgresColumns[query.Field]
+		operator, supported := postgresOperators[query.Operand]
+		if !ok || !supported {
+			return nil, nil, errors.New(fmt.Sprintf("unsupported query %s %s", query.Field, query.Operand))
+		}
+		value := query.Value
+		if query.Operand == "BEGINS_WITH" {
+			value = escapeLike(value) + "%"
+		}
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s %s $%d", column, operator, len(args)))
+	}
+	for _, betweenQuery := range betweenQueries {
+		column, ok := postgresColumns[betweenQuery.Field]
+		if !ok {
+			return nil, nil, errors.New(fmt.Sprintf("unsupported query %s BETWEEN", betweenQuery.Field))
+		}
+		args = append(args, betweenQuery.Init, betweenQuery.End)
+		conditions = append(conditions, fmt.Sprintf("%s BETWEEN $%d AND $%d", column, len(args)-1, len(args)))
+	}
+	return conditions, args, nil
+}
+
+func (receiver *PostgresClient) queryRecords(ctx context.Context, querier postgresQuerier, statement string, args ...interface{}) ([]*models.Record, error) {
+	rows, err := querier.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, postgresError(err)
+	}
+	defer rows.Close()
+	records := make([]*models.Record, 0, MaxBatchSize)
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			return nil, err
+		}
+		record := &models.Record{}
+		err = json.Unmarshal(data, record)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, postgresError(rows.Err())
+}
+
+// postgresError classifies the failures of postgres calls into the store
+// errors, other errors are returned as they are.
+func postgresError(err error) error {
+	var classified *storeError
+	var netErr net.Error
+	var pqErr *pq.Error
+	switch {
+	case errors.As(err, &classified):
+		return err
+	case errors.As(err, &netErr) || errors.Is(err, driver.ErrBadConn):
+		return &storeError{ErrUnavailable, err}
+	case errors.As(err, &pqErr) && postgresErrorClasses[pqErr.Code.Class()] != nil:
+		return &storeError{postgresErrorClasses[pqErr.Code.Class()], err}
+	}
+	return err
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func dereference(ids []*string) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, *id)
+	}
+	return values
+}
+
+//Save
+
+func (receiver *PostgresClient) SaveRecord(ctx context.Context, record *models.Record, mode models.SaveMode, expectedVersion int64) (*models.Record, error) {
+	err := receiver.inTransaction(ctx, func(tx *sql.Tx) error {
+		stored, err := receiver.lockRecord(ctx, tx, record.Id())
+		if err != nil {
+			return err
+		}
+		record, err = resolveSave(stored, record, mode, expectedVersion, time.Now())
+		if err != nil {
+			return err
+		}
+		return receiver.upsertRecords(ctx, tx, []*models.Record{record})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// lockRecord reads the stored record, nil when absent, and keeps other writes
+// of the same id waiting until the transaction ends.
+func (receiver *PostgresClient) lockRecord(ctx context.Context, tx *sql.Tx, id string) (*models.Record, error) {
+	// the advisory lock covers ids which have no row to lock yet
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, id)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := receiver.queryRecords(ctx, tx, receiver.sql(`SELECT to_json(r) FROM "{table}" r WHERE id = $1 FOR UPDATE`), id)
+	if err != nil || len(stored) == 0 {
+		return nil, err
+	}
+	return stored[0], nil
+}
+
+func (receiver *PostgresClient) SaveBatchRecords(ctx context.Context, records []*models.Record) ([]*models.Record, error) {
+	if err := checkBatchSize(len(records)); err != nil {
+		return nil, err
+	}
+	err := receiver.inTransaction(ctx, func(tx *sql.Tx) error {
+		return receiver.upsertRecords(ctx, tx, records)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (receiver *PostgresClient) upsertRecords(ctx context.Context, tx *sql.Tx, records []*models.Record) error {
+	statement, err := tx.PrepareContext(ctx, receiver.sql(`INSERT INTO "{table}" (id, record_id, client_id, product_id, added_date, expires_at, reason_code, note, source, created_by, labels, updated_at, version, kind, list, tenant)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (id) DO UPDATE SET
+			record_id = excluded.record_id,
+			client_id = excluded.client_id,
+			product_id = excluded.product_id,
+			added_date = excluded.added_date,
+			expires_at = excluded.expires_at,
+			reason_code = excluded.reason_code,
+			note = excluded.note,
+			source = excluded.source,
+			created_by = excluded.created_by,
+			labels = excluded.labels,
+			updated_at = excluded.updated_at,
+			version = excluded.version,
+			kind = excluded.kind,
+			list = excluded.list,
+			tenant = excluded.tenant`))
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+	for _, record := range records {
+		expiresAt := sql.NullTime{Time: record.ExpiresAt(), Valid: !record.ExpiresAt().IsZero()}
+		labels := sql.NullString{}
+		if len(record.Labels()) > 0 {
+			data, err := json.Marshal(record.Labels())
+			if err != nil {
+				return err
+			}
+			labels = sql.NullString{String: string(data), Valid: true}
+		}
+		updatedAt := sql.NullString{String: record.Value("updated_at"), Valid: record.Value("updated_at") != ""}
+		_, err = statement.ExecContext(ctx, record.Id(), record.Value("record_id"), record.Value("client_id"), record.Value("product_id"), record.Value("added_date"), expiresAt,
+			record.Value("reason_code"), record.Value("note"), record.Value("source"), record.Value("created_by"), labels, updatedAt, record.Version(), record.Value("kind"), record.List(), record.Tenant())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (receiver *PostgresClient) inTransaction(ctx context.Context, operation func(tx *sql.Tx) error) error {
+	tx, err := receiver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return postgresError(err)
+	}
+	err = operation(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return postgresError(err)
+	}
+	return postgresError(tx.Commit())
+}
+
+//Update
+
+func (receiver *PostgresClient) UpdateRecord(ctx context.Context, id *string, update *models.RecordUpdate, expectedVersion int64) (*models.Record, error) {
+	var record *models.Record
+	err := receiver.inTransaction(ctx, func(tx *sql.Tx) error {
+		stored, err := receiver.lockRecord(ctx, tx, *id)
+		if err != nil {
+			return err
+		}
+		record, err = resolveUpdate(stored, update, expectedVersion, time.Now())
+		if err != nil {
+			return err
+		}
+		return receiver.upsertRecords(ctx, tx, []*models.Record{record})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+//Delete
+
+func (receiver *PostgresClient) DeleteRecord(ctx context.Context, id *string, expectedVersion int64) error {
+	if expectedVersion == 0 {
+		_, err := receiver.db.ExecContext(ctx, receiver.sql(`DELETE FROM "{table}" WHERE id = $1`), *id)
+		return postgresError(err)
+	}
+	result, err := receiver.db.ExecContext(ctx, receiver.sql(`DELETE FROM "{table}" WHERE id = $1 AND version = $2 AND `+notExpired), *id, expectedVersion)
+	if err != nil {
+		return postgresError(err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+func (receiver *PostgresClient) DeleteBatchRecords(ctx context.Context, ids []*string) error {
+	if err := checkBatchSize(len(ids)); err != nil {
+		return err
+	}
+	return receiver.inTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, receiver.sql(`DELETE FROM "{table}" WHERE id = ANY($1)`), pq.Array(dereference(ids)))
+		return err
+	})
+}
+
+func (receiver *PostgresClient) DeleteRecordsByQueries(ctx context.Context, queries []*models.Query) error {
+	conditions, args, err := postgresConditions(queries, nil)
+	if err != nil {
+		return err
+	}
+	statement := `DELETE FROM "{table}"`
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	_, err = receiver.db.ExecContext(ctx, receiver.sql(statement), args...)
+	return postgresError(err)
+}
+
+//Lists
+
+func (receiver *PostgresClient) GetList(ctx context.Context, name string) (*models.List, error) {
+	var data []byte
+	err := receiver.db.QueryRowContext(ctx, receiver.sql(`SELECT to_json(l) FROM "{table}_lists" l WHERE name = $1`), name).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, postgresError(err)
+	}
+	list := &models.List{}
+	err = json.Unmarshal(data, list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (receiver *PostgresClient) CreateList(ctx context.Context, list *models.List) error {
+	result, err := receiver.db.ExecContext(ctx, receiver.sql(`INSERT INTO "{table}_lists" (name, default_ttl_seconds, max_batch_size, created_at)
+		VALUES ($1, $2, $3, $4) ON CONFLICT (name) DO NOTHING`),
+		list.Name(), int64(list.DefaultTtl()/time.Second), list.MaxBatchSize(), list.CreatedAt())
+	if err != nil {
+		return postgresError(err)
+	}
+	created, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if created == 0 {
+		return ErrListExists
+	}
+	return nil
+}
+
+func (receiver *PostgresClient) DeleteList(ctx context.Context, name string) error {
+	result, err := receiver.db.ExecContext(ctx, receiver.sql(`DELETE FROM "{table}_lists" WHERE name = $1`), name)
+	if err != nil {
+		return postgresError(err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrListNotFound
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package clients
+
+import (
+	"blacklist/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxBatchSize is the most items a store takes in a single batch operation, the
+// limit of DynamoDB BatchWriteItem.
+const MaxBatchSize = 25
+
+var (
+	ErrMaxBatchExceeded = errors.New(fmt.Sprintf("ids list has more than the store max batch (%d)", MaxBatchSize))
+	ErrAlreadyExists    = errors.New("record already exists")
+	ErrVersionConflict  = errors.New("record does not have the expected version")
+	ErrNotFound         = errors.New("record does not exist")
+	ErrListExists       = errors.New("list already exists")
+	ErrListNotFound     = errors.New("list does not exist")
+	// Failures of the underlying database are classified into these, so callers
+	// can tell the ones worth retrying without knowing which store they use
+	ErrThrottled      = errors.New("the store is throttling requests")
+	ErrUnavailable    = errors.New("the store is unavailable")
+	ErrInvalidRequest = errors.New("the store rejected the request")
+)
+
+// storeError keeps the message of a failure of the underlying database while
+// matching the sentinel it was classified as.
+type storeError struct {
+	sentinel error
+	cause    error
+}
+
+func (receiver *storeError) Error() string {
+	return receiver.cause.Error()
+}
+
+func (receiver *storeError) Is(target error) bool {
+	return target == receiver.sentinel
+}
+
+func (receiver *storeError) Unwrap() error {
+	return receiver.cause
+}
+
+func checkBatchSize(size int) error {
+	if size > MaxBatchSize {
+		return ErrMaxBatchExceeded
+	}
+	return nil
+}
+
+// resolveSave tells what has to be written when saving record over the stored
+// one (nil when absent) with the given mode and expected version, 0 meaning any.
+// The default expiration of the record only applies when none is kept.
+// Versions keep counting over expired records so an old version never matches again.
+func resolveSave(stored, record *models.Record, mode models.SaveMode, expectedVersion int64, now time.Time) (*models.Record, error) {
+	version := int64(0)
+	if stored != nil {
+		version = stored.Version()
+	}
+	if stored != nil && stored.Expired(now) {
+		stored = nil
+	}
+	if stored != nil && mode == models.SaveCreate {
+		return nil, ErrAlreadyExists
+	}
+	err := checkVersion(stored, expectedVersion, now)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil && mode == models.SaveUpsert {
+		record = record.Preserving(stored)
+	}
+	return record.WithDefaultExpiration().WithVersion(version + 1), nil
+}
+
+// resolveUpdate applies the update to the stored record, nil when absent, and
+// increments its version.
+func resolveUpdate(stored *models.Record, update *models.RecordUpdate, expectedVersion int64, now time.Time) (*models.Record, error) {
+	if stored == nil || stored.Expired(now) {
+		return nil, ErrNotFound
+	}
+	err := checkVersion(stored, expectedVersion, now)
+	if err != nil {
+		return nil, err
+	}
+	return update.Apply(stored, now).WithVersion(stored.Version() + 1), nil
+}
+
+// checkVersion fails when a version is expected and the stored record, nil when
+// absent, is expired or does not have it.
+func checkVersion(stored *models.Record, expectedVersion int64, now time.Time) error {
+	if expectedVersion == 0 {
+		return nil
+	}
+	if stored == nil || stored.Expired(now) || stored.Version() != expectedVersion {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// RecordStore is implemented by every storage backend. Batch operations take at
+// most MaxBatchSize items and may return a partial result together with an
+// *UnprocessedError naming the items that could not be processed. Batch saves
+// write the records as given, version included, and are meant for bulk
+// rewrites; SaveRecord honours the models.SaveMode and increments the version.
+// An expected version of 0 skips the version check. DeleteRecordsByQueries
+// deletes every record matching the queries, expired ones included. Lists are
+// kept apart from the records, deleting one leaves its records in place.
+type RecordStore interface {
+	GetRecordById(ctx context.Context, id *string) (*models.Record, error)
+	GetRecordBatchByIds(ctx context.Context, ids []*string) ([]*models.Record, error)
+	GetRecordsByQueries(ctx context.Context, queries []*models.Query, betweenQueries []*models.BetweenQuery, lastKey *string) ([]*models.Record, *string, error)
+	SaveRecord(ctx context.Context, record *models.Record, mode models.SaveMode, expectedVersion int64) (*models.Record, error)
+	SaveBatchRecords(ctx context.Context, records []*models.Record) ([]*models.Record, error)
+	UpdateRecord(ctx context.Context, id *string, update *models.RecordUpdate, expectedVersion int64) (*models.Record, error)
+	DeleteRecord(ctx context.Context, id *string, expectedVersion int64) error
+	DeleteBatchRecords(ctx context.Context, ids []*string) error
+	DeleteRecordsByQueries(ctx context.Context, queries []*models.Query) error
+	GetList(ctx context.Context, name string) (*models.List, error)
+	CreateList(ctx context.Context, list *models.List) error
+	DeleteList(ctx context.Context, name string) error
+}
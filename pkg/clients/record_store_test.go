@@ -0,0 +1,129 @@
+package clients
+
+import (
+	"blacklist/models"
+	"blacklist/tools/protos"
+	"context"
+	"fmt"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// listRecords returns a live and an expired record of every list.
+func listRecords(lists ...string) []*models.Record {
+	records := make([]*models.Record, 0, 2*len(lists))
+	for _, list := range lists {
+		records = append(records,
+			models.FromOperationRequest(&blacklist.BlacklistRecordOperationRequest{List: list, RecordId: "live", ClientId: "c", ProductId: "p"}),
+			models.FromOperationRequest(&blacklist.BlacklistRecordOperationRequest{List: list, RecordId: "expired", ClientId: "c", ProductId: "p", ExpiresAt: timestamppb.New(time.Now().Add(-time.Hour))}),
+		)
+	}
+	return records
+}
+
+// storedIds returns the ids of every record kept by the store, expired ones
+// included.
+func storedIds(t *testing.T, store RecordStore) []string {
+	var ids []string
+	switch store := store.(type) {
+	case *MemoryClient:
+		for id := range store.records {
+			ids = append(ids, id)
+		}
+	case *BoltClient:
+		err := store.db.View(func(tx *bolt.Tx) error {
+			return tx.Bucket(recordsBucket).ForEach(func(key, _ []byte) error {
+				ids = append(ids, string(key))
+				return nil
+			})
+		})
+		if err != nil {
+			t.Fatalf("reading the stored ids: %v", err)
+		}
+	case *PostgresClient:
+		rows, err := store.db.Query(store.sql(`SELECT id FROM "{table}"`))
+		if err != nil {
+			t.Fatalf("reading the stored ids: %v", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				t.Fatalf("reading the stored ids: %v", err)
+			}
+			ids = append(ids, id)
+		}
+	default:
+		t.Fatalf("can not read the ids stored by %T", store)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func newTestBoltClient(t *testing.T) *BoltClient {
+	client, err := NewBoltClient(filepath.Join(t.TempDir(), "blacklist.db"))
+	if err != nil {
+		t.Fatalf("opening the client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+func TestDeleteRecordsByQueriesDeletesExpiredRecords(t *testing.T) {
+	stores := map[string]func(t *testing.T) RecordStore{
+		"memory":   func(t *testing.T) RecordStore { return NewMemoryClient() },
+		"bolt":     func(t *testing.T) RecordStore { return newTestBoltClient(t) },
+		"postgres": func(t *testing.T) RecordStore { return newTestPostgresClient(t) },
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			ctx := context.Background()
+			_, err := store.SaveBatchRecords(ctx, listRecords("fraud", "other"))
+			if err != nil {
+				t.Fatalf("saving the records: %v", err)
+			}
+			err = store.DeleteRecordsByQueries(ctx, []*models.Query{{Field: models.ListAttribute, Operand: "EQUALS", Value: "fraud"}})
+			if err != nil {
+				t.Fatalf("deleting the records: %v", err)
+			}
+			want := make([]string, 0, 2)
+			for _, record := range listRecords("other") {
+				want = append(want, record.Id())
+			}
+			sort.Strings(want)
+			if got := storedIds(t, store); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("stored ids = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestTenantStoreDeletesOnlyItsRecords(t *testing.T) {
+	store := NewMemoryClient()
+	ctx := context.Background()
+	for _, tenant := range []string{"owner", "other"} {
+		_, err := NewTenantStore(store, tenant).SaveBatchRecords(ctx, listRecords("fraud"))
+		if err != nil {
+			t.Fatalf("saving the records of %s: %v", tenant, err)
+		}
+	}
+	err := NewTenantStore(store, "other").DeleteRecordsByQueries(ctx, []*models.Query{{Field: models.ListAttribute, Operand: "EQUALS", Value: "fraud"}})
+	if err != nil {
+		t.Fatalf("deleting the records: %v", err)
+	}
+	want := make([]string, 0, 2)
+	for _, record := range listRecords("fraud") {
+		want = append(want, record.WithTenant("owner").Id())
+	}
+	sort.Strings(want)
+	if got := storedIds(t, store); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("stored ids = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	DefaultRetryPolicy = RetryPolicy{MaxAttempts: 8, BaseDelay: 50 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+	jitterMu sync.Mutex
+	jitter   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// RetryPolicy bounds how many times and how often a partially processed batch
+// is sent again. Delays grow exponentially from BaseDelay up to MaxDelay and a
+// random jitter is applied, so throttled callers do not retry in lockstep.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// UnprocessedError is returned by batch operations when some items could not
+// be processed within the retry budget. The other items of the batch did succeed.
+type UnprocessedError struct {
+	Ids []string
+}
+
+func (receiver *UnprocessedError) Error() string {
+	return fmt.Sprintf("%d items could not be processed after retrying: %s", len(receiver.Ids), strings.Join(receiver.Ids, ", "))
+}
+
+func (receiver RetryPolicy) orDefault() RetryPolicy {
+	if receiver.MaxAttempts <= 0 {
+		return DefaultRetryPolicy
+	}
+	return receiver
+}
+
+// Delay returns how long to wait after the given failed attempt, starting at 1.
+func (receiver RetryPolicy) Delay(attempt int) time.Duration {
+	delay := receiver.MaxDelay
+	if attempt < 32 && receiver.BaseDelay<<(attempt-1) < receiver.MaxDelay {
+		delay = receiver.BaseDelay << (attempt - 1)
+	}
+	if delay <= 0 {
+		return 0
+	}
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return delay/2 + time.Duration(jitter.Int63n(int64(delay/2)+1))
+}
+
+// Wait sleeps the delay of the given attempt, returning early if ctx finishes.
+func (receiver RetryPolicy) Wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(receiver.Delay(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
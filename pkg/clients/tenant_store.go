@@ -0,0 +1,159 @@
+package clients
+
+import (
+	"blacklist/models"
+	"context"
+	"errors"
+	"strings"
+)
+
+// TenantStore confines a RecordStore to the records and lists of a single
+// tenant. Ids and list names are prefixed with models.TenantKey before reaching
+// the store, records are saved with the tenant and queries only match the ones
+// holding it, so nothing of another tenant can be read, written or deleted.
+// Records and lists are returned without the tenant, as if they were the only
+// ones stored.
+type TenantStore struct {
+	store  RecordStore
+	tenant string
+}
+
+func NewTenantStore(store RecordStore, tenant string) *TenantStore {
+	return &TenantStore{store, tenant}
+}
+
+func (receiver *TenantStore) key(id string) string {
+	return models.TenantKey(receiver.tenant, id)
+}
+
+func (receiver *TenantStore) keys(ids []*string) []*string {
+	keys := make([]*string, 0, len(ids))
+	for _, id := range ids {
+		key := receiver.key(*id)
+		keys = append(keys, &key)
+	}
+	return keys
+}
+
+func (receiver *TenantStore) strip(record *models.Record) *models.Record {
+	if record == nil {
+		return nil
+	}
+	return record.WithTenant("")
+}
+
+func (receiver *TenantStore) stripAll(records []*models.Record) []*models.Record {
+	stripped := make([]*models.Record, 0, len(records))
+	for _, record := range records {
+		stripped = append(stripped, receiver.strip(record))
+	}
+	return stripped
+}
+
+// stripError removes the tenant from the ids an *UnprocessedError names.
+func (receiver *TenantStore) stripError(err error) error {
+	var unprocessedErr *UnprocessedError
+	if !errors.As(err, &unprocessedErr) {
+		return err
+	}
+	prefix := receiver.key("")
+	ids := make([]string, 0, len(unprocessedErr.Ids))
+	for _, id := range unprocessedErr.Ids {
+		ids = append(ids, strings.TrimPrefix(id, prefix))
+	}
+	return &UnprocessedError{Ids: ids}
+}
+
+// queries adds to the given ones the query matching the records of the tenant.
+func (receiver *TenantStore) queries(queries []*models.Query) []*models.Query {
+	tenantQueries := make([]*models.Query, 0, len(queries)+1)
+	tenantQueries = append(tenantQueries, queries...)
+	return append(tenantQueries, &models.Query{Field: models.TenantAttribute, Operand: "EQUALS", Value: receiver.tenant})
+}
+
+//Get
+
+func (receiver *TenantStore) GetRecordById(ctx context.Context, id *string) (*models.Record, error) {
+	key := receiver.key(*id)
+	record, err := receiver.store.GetRecordById(ctx, &key)
+	if err != nil {
+		return nil, err
+	}
+	return receiver.strip(record), nil
+}
+
+func (receiver *TenantStore) GetRecordBatchByIds(ctx context.Context, ids []*string) ([]*models.Record, error) {
+	records, err := receiver.store.GetRecordBatchByIds(ctx, receiver.keys(ids))
+	return receiver.stripAll(records), receiver.stripError(err)
+}
+
+func (receiver *TenantStore) GetRecordsByQueries(ctx context.Context, queries []*models.Query, betweenQueries []*models.BetweenQuery, lastKey *string) ([]*models.Record, *string, error) {
+	records, nextKey, err := receiver.store.GetRecordsByQueries(ctx, receiver.queries(queries), betweenQueries, lastKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return receiver.stripAll(records), nextKey, nil
+}
+
+//Save
+
+func (receiver *TenantStore) SaveRecord(ctx context.Context, record *models.Record, mode models.SaveMode, expectedVersion int64) (*models.Record, error) {
+	saved, err := receiver.store.SaveRecord(ctx, record.WithTenant(receiver.tenant), mode, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return receiver.strip(saved), nil
+}
+
+func (receiver *TenantStore) SaveBatchRecords(ctx context.Context, records []*models.Record) ([]*models.Record, error) {
+	owned := make([]*models.Record, 0, len(records))
+	for _, record := range records {
+		owned = append(owned, record.WithTenant(receiver.tenant))
+	}
+	saved, err := receiver.store.SaveBatchRecords(ctx, owned)
+	return receiver.stripAll(saved), receiver.stripError(err)
+}
+
+//Update
+
+func (receiver *TenantStore) UpdateRecord(ctx context.Context, id *string, update *models.RecordUpdate, expectedVersion int64) (*models.Record, error) {
+	key := receiver.key(*id)
+	record, err := receiver.store.UpdateRecord(ctx, &key, update, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return receiver.strip(record), nil
+}
+
+//Delete
+
+func (receiver *TenantStore) DeleteRecord(ctx context.Context, id *string, expectedVersion int64) error {
+	key := receiver.key(*id)
+	return receiver.store.DeleteRecord(ctx, &key, expectedVersion)
+}
+
+func (receiver *TenantStore) DeleteBatchRecords(ctx context.Context, ids []*string) error {
+	return receiver.stripError(receiver.store.DeleteBatchRecords(ctx, receiver.keys(ids)))
+}
+
+func (receiver *TenantStore) DeleteRecordsByQueries(ctx context.Context, queries []*models.Query) error {
+	return receiver.stripError(receiver.store.DeleteRecordsByQueries(ctx, receiver.queries(queries)))
+}
+
+//Lists
+
+func (receiver *TenantStore) GetList(ctx context.Context, name string) (*models.List, error) {
+	list, err := receiver.store.GetList(ctx, receiver.key(name))
+	if err != nil || list == nil {
+		return nil, err
+	}
+	return list.WithName(name), nil
+}
+
+func (receiver *TenantStore) CreateList(ctx context.Context, list *models.List) error {
+	return receiver.store.CreateList(ctx, list.WithName(receiver.key(list.Name())))
+}
+
+func (receiver *TenantStore) DeleteList(ctx context.Context, name string) error {
+	return receiver.store.DeleteList(ctx, receiver.key(name))
+}
@@ -0,0 +1,128 @@
+package migrations
+
+import (
+	"blacklist/models"
+	"blacklist/pkg/clients"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+var (
+	unknownMigration = "unknown migration %s"
+	skippedOldIds    = "Skipped %d records stored under an older id, run the rekey migration before rewriting them"
+)
+
+// Run applies the named one-off migration to every record of the store.
+func Run(ctx context.Context, store clients.RecordStore, name string) error {
+	switch name {
+	case "added-date":
+		skipped := 0
+		count, err := forEachBatch(ctx, store, func(ctx context.Context, records []*models.Record) error {
+			current := make([]*models.Record, 0, len(records))
+			for _, record := range records {
+				// saving it would leave a copy under its current id next to the stored one
+				if record.StoredId() != "" && record.StoredId() != record.Id() {
+					skipped++
+					continue
+				}
+				current = append(current, record)
+			}
+			if len(current) == 0 {
+				return nil
+			}
+			_, err := store.SaveBatchRecords(ctx, current)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		log.Printf("Rewrote added_date of %d records", count-skipped)
+		if skipped > 0 {
+			log.Printf(skippedOldIds, skipped)
+		}
+		return nil
+	case "rekey":
+		rekeyed := 0
+		_, err := forEachBatch(ctx, store, func(ctx context.Context, records []*models.Record) error {
+			for _, record := range records {
+				moved, err := rekey(ctx, store, record)
+				if err != nil {
+					return err
+				}
+				if moved {
+					rekeyed++
+				}
+			}
+			return nil
+		})
+		log.Printf("Rekeyed %d records", rekeyed)
+		return err
+	}
+	return errors.New(fmt.Sprintf(unknownMigration, name))
+}
+
+// forEachBatch pages through every record of the store and calls migrate with
+// batches of at most clients.MaxBatchSize of them, it returns how many records
+// were migrated before the first error.
+func forEachBatch(ctx context.Context, store clients.RecordStore, migrate func(ctx context.Context, records []*models.Record) error) (int, error) {
+	count := 0
+	var lastKey *string
+	for {
+		records, nextKey, err := store.GetRecordsByQueries(ctx, nil, nil, lastKey)
+		if err != nil {
+			return count, err
+		}
+		for start := 0; start < len(records); start += clients.MaxBatchSize {
+			end := start + clients.MaxBatchSize
+			if end > len(records) {
+				end = len(records)
+			}
+			err = migrate(ctx, records[start:end])
+			if err != nil {
+				return count, err
+			}
+			count += end - start
+		}
+		if nextKey == nil {
+			return count, nil
+		}
+		lastKey = nextKey
+	}
+}
+
+// rekey moves a record stored under an id other than its canonical one. When a
+// record already exists under the canonical id the most recently written of the
+// two is kept.
+func rekey(ctx context.Context, store clients.RecordStore, record *models.Record) (bool, error) {
+	storedId := record.StoredId()
+	id := record.Id()
+	if storedId == "" || storedId == id {
+		return false, nil
+	}
+	existing, err := store.GetRecordById(ctx, &id)
+	if err != nil {
+		return false, err
+	}
+	if existing == nil || lastWritten(record).After(lastWritten(existing)) {
+		_, err = store.SaveBatchRecords(ctx, []*models.Record{record})
+		if err != nil {
+			return false, err
+		}
+	}
+	err = store.DeleteRecord(ctx, &storedId, 0)
+	if err != nil {
+		return false, err
+	}
+	log.Printf("Moved record %s to %s", storedId, id)
+	return true, nil
+}
+
+func lastWritten(record *models.Record) time.Time {
+	if record.UpdatedAt().IsZero() {
+		return record.AddedDate()
+	}
+	return record.UpdatedAt()
+}
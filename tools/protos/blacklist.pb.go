@@ -9,6 +9,9 @@ package blacklist
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,13 +23,229 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Allowlist entries exempt their record, client and product from blocks. Checks
+// apply the most specific entry of either kind, an allowlist entry winning over
+// a block of the same scope.
+type RecordKind int32
+
+const (
+	RecordKind_BLOCK RecordKind = 0
+	RecordKind_ALLOW RecordKind = 1
+)
+
+// Enum value maps for RecordKind.
+var (
+	RecordKind_name = map[int32]string{
+		0: "BLOCK",
+		1: "ALLOW",
+	}
+	RecordKind_value = map[string]int32{
+		"BLOCK": 0,
+		"ALLOW": 1,
+	}
+)
+
+func (x RecordKind) Enum() *RecordKind {
+	p := new(RecordKind)
+	*p = x
+	return p
+}
+
+func (x RecordKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecordKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_tools_protos_blacklist_proto_enumTypes[0].Descriptor()
+}
+
+func (RecordKind) Type() protoreflect.EnumType {
+	return &file_tools_protos_blacklist_proto_enumTypes[0]
+}
+
+func (x RecordKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecordKind.Descriptor instead.
+func (RecordKind) EnumDescriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{0}
+}
+
+// Which clients and products a block covers. Lookups of a specific record,
+// client and product also match the blocks covering all of its clients or
+// products.
+type BlockScope int32
+
+const (
+	// Only the given record, client and product
+	BlockScope_EXACT BlockScope = 0
+	// The given record and client for every product, product_id is ignored
+	BlockScope_ALL_PRODUCTS BlockScope = 1
+	// The given record and product for every client, client_id is ignored
+	BlockScope_ALL_CLIENTS BlockScope = 2
+	// The given record for every client and product
+	BlockScope_ALL_CLIENTS_AND_PRODUCTS BlockScope = 3
+)
+
+// Enum value maps for BlockScope.
+var (
+	BlockScope_name = map[int32]string{
+		0: "EXACT",
+		1: "ALL_PRODUCTS",
+		2: "ALL_CLIENTS",
+		3: "ALL_CLIENTS_AND_PRODUCTS",
+	}
+	BlockScope_value = map[string]int32{
+		"EXACT":                    0,
+		"ALL_PRODUCTS":             1,
+		"ALL_CLIENTS":              2,
+		"ALL_CLIENTS_AND_PRODUCTS": 3,
+	}
+)
+
+func (x BlockScope) Enum() *BlockScope {
+	p := new(BlockScope)
+	*p = x
+	return p
+}
+
+func (x BlockScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlockScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_tools_protos_blacklist_proto_enumTypes[1].Descriptor()
+}
+
+func (BlockScope) Type() protoreflect.EnumType {
+	return &file_tools_protos_blacklist_proto_enumTypes[1]
+}
+
+func (x BlockScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlockScope.Descriptor instead.
+func (BlockScope) EnumDescriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{1}
+}
+
+// How a save treats a record already stored under the same id, expired records
+// count as absent
+type SaveMode int32
+
+const (
+	// Keeps the added date, creator and any metadata left empty of the stored record
+	SaveMode_UPSERT SaveMode = 0
+	// Fails with ALREADY_EXISTS
+	SaveMode_CREATE SaveMode = 1
+	// Replaces the whole stored record
+	SaveMode_OVERWRITE SaveMode = 2
+)
+
+// Enum value maps for SaveMode.
+var (
+	SaveMode_name = map[int32]string{
+		0: "UPSERT",
+		1: "CREATE",
+		2: "OVERWRITE",
+	}
+	SaveMode_value = map[string]int32{
+		"UPSERT":    0,
+		"CREATE":    1,
+		"OVERWRITE": 2,
+	}
+)
+
+func (x SaveMode) Enum() *SaveMode {
+	p := new(SaveMode)
+	*p = x
+	return p
+}
+
+func (x SaveMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SaveMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_tools_protos_blacklist_proto_enumTypes[2].Descriptor()
+}
+
+func (SaveMode) Type() protoreflect.EnumType {
+	return &file_tools_protos_blacklist_proto_enumTypes[2]
+}
+
+func (x SaveMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SaveMode.Descriptor instead.
+func (SaveMode) EnumDescriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{2}
+}
+
+type BatchItemStatus int32
+
+const (
+	BatchItemStatus_OK        BatchItemStatus = 0
+	BatchItemStatus_NOT_FOUND BatchItemStatus = 1
+	BatchItemStatus_INVALID   BatchItemStatus = 2
+	BatchItemStatus_FAILED    BatchItemStatus = 3
+)
+
+// Enum value maps for BatchItemStatus.
+var (
+	BatchItemStatus_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+		2: "INVALID",
+		3: "FAILED",
+	}
+	BatchItemStatus_value = map[string]int32{
+		"OK":        0,
+		"NOT_FOUND": 1,
+		"INVALID":   2,
+		"FAILED":    3,
+	}
+)
+
+func (x BatchItemStatus) Enum() *BatchItemStatus {
+	p := new(BatchItemStatus)
+	*p = x
+	return p
+}
+
+func (x BatchItemStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchItemStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_tools_protos_blacklist_proto_enumTypes[3].Descriptor()
+}
+
+func (BatchItemStatus) Type() protoreflect.EnumType {
+	return &file_tools_protos_blacklist_proto_enumTypes[3]
+}
+
+func (x BatchItemStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchItemStatus.Descriptor instead.
+func (BatchItemStatus) EnumDescriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{3}
+}
+
 type SupportedQueryField int32
 
 const (
-	SupportedQueryField_record_id  SupportedQueryField = 0
-	SupportedQueryField_client_id  SupportedQueryField = 1
-	SupportedQueryField_product_id SupportedQueryField = 2
-	SupportedQueryField_added_date SupportedQueryField = 4
+	SupportedQueryField_record_id   SupportedQueryField = 0
+	SupportedQueryField_client_id   SupportedQueryField = 1
+	SupportedQueryField_product_id  SupportedQueryField = 2
+	SupportedQueryField_added_date  SupportedQueryField = 4
+	SupportedQueryField_reason_code SupportedQueryField = 5
+	SupportedQueryField_source      SupportedQueryField = 6
 )
 
 // Enum value maps for SupportedQueryField.
@@ -36,12 +255,16 @@ var (
 		1: "client_id",
 		2: "product_id",
 		4: "added_date",
+		5: "reason_code",
+		6: "source",
 	}
 	SupportedQueryField_value = map[string]int32{
-		"record_id":  0,
-		"client_id":  1,
-		"product_id": 2,
-		"added_date": 4,
+		"record_id":   0,
+		"client_id":   1,
+		"product_id":  2,
+		"added_date":  4,
+		"reason_code": 5,
+		"source":      6,
 	}
 )
 
@@ -56,11 +279,11 @@ func (x SupportedQueryField) String() string {
 }
 
 func (SupportedQueryField) Descriptor() protoreflect.EnumDescriptor {
-	return file_tools_protos_blacklist_proto_enumTypes[0].Descriptor()
+	return file_tools_protos_blacklist_proto_enumTypes[4].Descriptor()
 }
 
 func (SupportedQueryField) Type() protoreflect.EnumType {
-	return &file_tools_protos_blacklist_proto_enumTypes[0]
+	return &file_tools_protos_blacklist_proto_enumTypes[4]
 }
 
 func (x SupportedQueryField) Number() protoreflect.EnumNumber {
@@ -69,7 +292,7 @@ func (x SupportedQueryField) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SupportedQueryField.Descriptor instead.
 func (SupportedQueryField) EnumDescriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{0}
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{4}
 }
 
 type SupportedQueryOperation int32
@@ -95,58 +318,617 @@ var (
 		"LESSER_THAN":  2,
 		"BEGINS_WITH":  3,
 	}
-)
+)
+
+func (x SupportedQueryOperation) Enum() *SupportedQueryOperation {
+	p := new(SupportedQueryOperation)
+	*p = x
+	return p
+}
+
+func (x SupportedQueryOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SupportedQueryOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_tools_protos_blacklist_proto_enumTypes[5].Descriptor()
+}
+
+func (SupportedQueryOperation) Type() protoreflect.EnumType {
+	return &file_tools_protos_blacklist_proto_enumTypes[5]
+}
+
+func (x SupportedQueryOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SupportedQueryOperation.Descriptor instead.
+func (SupportedQueryOperation) EnumDescriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{5}
+}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tools_protos_blacklist_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{0}
+}
+
+type BlacklistRecordDto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordId   string                 `protobuf:"bytes,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	ClientId   string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ProductId  string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	AddedDate  *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=added_date,json=addedDate,proto3" json:"added_date,omitempty"`
+	ExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ReasonCode string                 `protobuf:"bytes,6,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	Note       string                 `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	Source     string                 `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	CreatedBy  string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Labels     map[string]string      `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Incremented on every write of the record
+	Version int64 `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	// Blocks covering every client or product hold * as that id
+	Scope BlockScope `protobuf:"varint,14,opt,name=scope,proto3,enum=BlockScope" json:"scope,omitempty"`
+	Kind  RecordKind `protobuf:"varint,15,opt,name=kind,proto3,enum=RecordKind" json:"kind,omitempty"`
+	List  string     `protobuf:"bytes,16,opt,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *BlacklistRecordDto) Reset() {
+	*x = BlacklistRecordDto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tools_protos_blacklist_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlacklistRecordDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlacklistRecordDto) ProtoMessage() {}
+
+func (x *BlacklistRecordDto) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlacklistRecordDto.ProtoReflect.Descriptor instead.
+func (*BlacklistRecordDto) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlacklistRecordDto) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+func (x *BlacklistRecordDto) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *BlacklistRecordDto) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BlacklistRecordDto) GetAddedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedDate
+	}
+	return nil
+}
+
+func (x *BlacklistRecordDto) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *BlacklistRecordDto) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *BlacklistRecordDto) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *BlacklistRecordDto) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *BlacklistRecordDto) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *BlacklistRecordDto) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BlacklistRecordDto) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *BlacklistRecordDto) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BlacklistRecordDto) GetScope() BlockScope {
+	if x != nil {
+		return x.Scope
+	}
+	return BlockScope_EXACT
+}
+
+func (x *BlacklistRecordDto) GetKind() RecordKind {
+	if x != nil {
+		return x.Kind
+	}
+	return RecordKind_BLOCK
+}
+
+func (x *BlacklistRecordDto) GetList() string {
+	if x != nil {
+		return x.List
+	}
+	return ""
+}
+
+type BlacklistRecordOperationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordId  string `protobuf:"bytes,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	ClientId  string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ProductId string `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Only used when saving, the record is treated as absent from then on
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Metadata only used when saving: why, from where and by whom the record is blocked
+	ReasonCode string            `protobuf:"bytes,5,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	Note       string            `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	Source     string            `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	CreatedBy  string            `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Labels     map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Mode       SaveMode          `protobuf:"varint,10,opt,name=mode,proto3,enum=SaveMode" json:"mode,omitempty"`
+	// When set, saves and deletes only go through while the stored record has
+	// this version and fail with ABORTED otherwise
+	ExpectedVersion int64 `protobuf:"varint,11,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// Addresses the block covering every client or product instead of a specific one
+	Scope BlockScope `protobuf:"varint,12,opt,name=scope,proto3,enum=BlockScope" json:"scope,omitempty"`
+	// Name of the list the record belongs to, empty for the default list
+	List string `protobuf:"bytes,13,opt,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *BlacklistRecordOperationRequest) Reset() {
+	*x = BlacklistRecordOperationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tools_protos_blacklist_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlacklistRecordOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlacklistRecordOperationRequest) ProtoMessage() {}
+
+func (x *BlacklistRecordOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlacklistRecordOperationRequest.ProtoReflect.Descriptor instead.
+func (*BlacklistRecordOperationRequest) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlacklistRecordOperationRequest) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+func (x *BlacklistRecordOperationRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *BlacklistRecordOperationRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BlacklistRecordOperationRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *BlacklistRecordOperationRequest) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *BlacklistRecordOperationRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *BlacklistRecordOperationRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *BlacklistRecordOperationRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *BlacklistRecordOperationRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BlacklistRecordOperationRequest) GetMode() SaveMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SaveMode_UPSERT
+}
+
+func (x *BlacklistRecordOperationRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *BlacklistRecordOperationRequest) GetScope() BlockScope {
+	if x != nil {
+		return x.Scope
+	}
+	return BlockScope_EXACT
+}
+
+func (x *BlacklistRecordOperationRequest) GetList() string {
+	if x != nil {
+		return x.List
+	}
+	return ""
+}
+
+// Changes the fields named in update_mask of an existing record to the values
+// given in record, a field in the mask left empty is cleared. Supported paths are
+// expires_at, reason_code, note, source and labels.
+type UpdateBlacklistRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record     *BlacklistRecordOperationRequest `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	UpdateMask *fieldmaskpb.FieldMask           `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+}
+
+func (x *UpdateBlacklistRecordRequest) Reset() {
+	*x = UpdateBlacklistRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tools_protos_blacklist_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBlacklistRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBlacklistRecordRequest) ProtoMessage() {}
+
+func (x *UpdateBlacklistRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBlacklistRecordRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBlacklistRecordRequest) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateBlacklistRecordRequest) GetRecord() *BlacklistRecordOperationRequest {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *UpdateBlacklistRecordRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type BlacklistBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requests []*BlacklistRecordOperationRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// Every request of the batch belongs to this list, the ones naming a
+	// different list are INVALID
+	List string `protobuf:"bytes,2,opt,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *BlacklistBatchRequest) Reset() {
+	*x = BlacklistBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tools_protos_blacklist_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlacklistBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlacklistBatchRequest) ProtoMessage() {}
+
+func (x *BlacklistBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlacklistBatchRequest.ProtoReflect.Descriptor instead.
+func (*BlacklistBatchRequest) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlacklistBatchRequest) GetRequests() []*BlacklistRecordOperationRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BlacklistBatchRequest) GetList() string {
+	if x != nil {
+		return x.List
+	}
+	return ""
+}
+
+// One result is streamed back per request of a batch, in the same order
+type BlacklistBatchItemResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status BatchItemStatus     `protobuf:"varint,2,opt,name=status,proto3,enum=BatchItemStatus" json:"status,omitempty"`
+	Error  string              `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Record *BlacklistRecordDto `protobuf:"bytes,4,opt,name=record,proto3" json:"record,omitempty"`
+	// gRPC status code of the error, 0 when the item succeeded
+	Code int32 `protobuf:"varint,5,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *BlacklistBatchItemResult) Reset() {
+	*x = BlacklistBatchItemResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tools_protos_blacklist_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlacklistBatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlacklistBatchItemResult) ProtoMessage() {}
+
+func (x *BlacklistBatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (x SupportedQueryOperation) Enum() *SupportedQueryOperation {
-	p := new(SupportedQueryOperation)
-	*p = x
-	return p
+// Deprecated: Use BlacklistBatchItemResult.ProtoReflect.Descriptor instead.
+func (*BlacklistBatchItemResult) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{5}
 }
 
-func (x SupportedQueryOperation) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+func (x *BlacklistBatchItemResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-func (SupportedQueryOperation) Descriptor() protoreflect.EnumDescriptor {
-	return file_tools_protos_blacklist_proto_enumTypes[1].Descriptor()
+func (x *BlacklistBatchItemResult) GetStatus() BatchItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BatchItemStatus_OK
 }
 
-func (SupportedQueryOperation) Type() protoreflect.EnumType {
-	return &file_tools_protos_blacklist_proto_enumTypes[1]
+func (x *BlacklistBatchItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
 }
 
-func (x SupportedQueryOperation) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
+func (x *BlacklistBatchItemResult) GetRecord() *BlacklistRecordDto {
+	if x != nil {
+		return x.Record
+	}
+	return nil
 }
 
-// Deprecated: Use SupportedQueryOperation.Descriptor instead.
-func (SupportedQueryOperation) EnumDescriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{1}
+func (x *BlacklistBatchItemResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
 }
 
-type Empty struct {
+type BlacklistCheckResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-}
 
-func (x *Empty) Reset() {
-	*x = Empty{}
+	RecordId    string              `protobuf:"bytes,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	ClientId    string              `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ProductId   string              `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Blacklisted bool                `protobuf:"varint,4,opt,name=blacklisted,proto3" json:"blacklisted,omitempty"`
+	Record      *BlacklistRecordDto `protobuf:"bytes,5,opt,name=record,proto3" json:"record,omitempty"`
+	// The block or allowlist entry which decided the outcome, unset when none applies
+	DecidedBy *BlacklistRecordDto `protobuf:"bytes,6,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"`
+	// Outcome of the request within IsBlacklistedBatch, which streams a result for
+	// every request. Checks answered are OK whether blacklisted or not, and
+	// blacklisted is false for the INVALID and FAILED ones
+	Status BatchItemStatus `protobuf:"varint,7,opt,name=status,proto3,enum=BatchItemStatus" json:"status,omitempty"`
+	Error  string          `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// gRPC status code of the error, 0 when the check succeeded
+	Code int32 `protobuf:"varint,9,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *BlacklistCheckResult) Reset() {
+	*x = BlacklistCheckResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tools_protos_blacklist_proto_msgTypes[0]
+		mi := &file_tools_protos_blacklist_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Empty) String() string {
+func (x *BlacklistCheckResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*BlacklistCheckResult) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_tools_protos_blacklist_proto_msgTypes[0]
+func (x *BlacklistCheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -157,39 +939,107 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use BlacklistCheckResult.ProtoReflect.Descriptor instead.
+func (*BlacklistCheckResult) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{6}
 }
 
-type BlacklistRecordDto struct {
+func (x *BlacklistCheckResult) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+func (x *BlacklistCheckResult) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *BlacklistCheckResult) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BlacklistCheckResult) GetBlacklisted() bool {
+	if x != nil {
+		return x.Blacklisted
+	}
+	return false
+}
+
+func (x *BlacklistCheckResult) GetRecord() *BlacklistRecordDto {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *BlacklistCheckResult) GetDecidedBy() *BlacklistRecordDto {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return nil
+}
+
+func (x *BlacklistCheckResult) GetStatus() BatchItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BatchItemStatus_OK
+}
+
+func (x *BlacklistCheckResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BlacklistCheckResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+// Lists keep records apart from the ones of other lists in the same store. The
+// default list, with an empty name, always exists.
+type ListDto struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RecordId  string `protobuf:"bytes,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
-	ClientId  string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	ProductId string `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	AddedDate string `protobuf:"bytes,4,opt,name=added_date,json=addedDate,proto3" json:"added_date,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Applied to the records saved without an expires_at, none when unset
+	DefaultTtl *durationpb.Duration `protobuf:"bytes,2,opt,name=default_ttl,json=defaultTtl,proto3" json:"default_ttl,omitempty"`
+	// The most requests accepted in a batch message, capped by the server one
+	// which also applies when 0
+	MaxBatchSize int32                  `protobuf:"varint,3,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 }
 
-func (x *BlacklistRecordDto) Reset() {
-	*x = BlacklistRecordDto{}
+func (x *ListDto) Reset() {
+	*x = ListDto{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tools_protos_blacklist_proto_msgTypes[1]
+		mi := &file_tools_protos_blacklist_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BlacklistRecordDto) String() string {
+func (x *ListDto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlacklistRecordDto) ProtoMessage() {}
+func (*ListDto) ProtoMessage() {}
 
-func (x *BlacklistRecordDto) ProtoReflect() protoreflect.Message {
-	mi := &file_tools_protos_blacklist_proto_msgTypes[1]
+func (x *ListDto) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -200,66 +1050,67 @@ func (x *BlacklistRecordDto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlacklistRecordDto.ProtoReflect.Descriptor instead.
-func (*BlacklistRecordDto) Descriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListDto.ProtoReflect.Descriptor instead.
+func (*ListDto) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *BlacklistRecordDto) GetRecordId() string {
+func (x *ListDto) GetName() string {
 	if x != nil {
-		return x.RecordId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *BlacklistRecordDto) GetClientId() string {
+func (x *ListDto) GetDefaultTtl() *durationpb.Duration {
 	if x != nil {
-		return x.ClientId
+		return x.DefaultTtl
 	}
-	return ""
+	return nil
 }
 
-func (x *BlacklistRecordDto) GetProductId() string {
+func (x *ListDto) GetMaxBatchSize() int32 {
 	if x != nil {
-		return x.ProductId
+		return x.MaxBatchSize
 	}
-	return ""
+	return 0
 }
 
-func (x *BlacklistRecordDto) GetAddedDate() string {
+func (x *ListDto) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AddedDate
+		return x.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-type BlacklistRecordOperationRequest struct {
+// Names are made of lowercase letters, digits, - and _, up to 64 characters
+type CreateListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RecordId  string `protobuf:"bytes,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
-	ClientId  string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	ProductId string `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name         string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DefaultTtl   *durationpb.Duration `protobuf:"bytes,2,opt,name=default_ttl,json=defaultTtl,proto3" json:"default_ttl,omitempty"`
+	MaxBatchSize int32                `protobuf:"varint,3,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
 }
 
-func (x *BlacklistRecordOperationRequest) Reset() {
-	*x = BlacklistRecordOperationRequest{}
+func (x *CreateListRequest) Reset() {
+	*x = CreateListRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tools_protos_blacklist_proto_msgTypes[2]
+		mi := &file_tools_protos_blacklist_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BlacklistRecordOperationRequest) String() string {
+func (x *CreateListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlacklistRecordOperationRequest) ProtoMessage() {}
+func (*CreateListRequest) ProtoMessage() {}
 
-func (x *BlacklistRecordOperationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tools_protos_blacklist_proto_msgTypes[2]
+func (x *CreateListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -270,57 +1121,58 @@ func (x *BlacklistRecordOperationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlacklistRecordOperationRequest.ProtoReflect.Descriptor instead.
-func (*BlacklistRecordOperationRequest) Descriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use CreateListRequest.ProtoReflect.Descriptor instead.
+func (*CreateListRequest) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *BlacklistRecordOperationRequest) GetRecordId() string {
+func (x *CreateListRequest) GetName() string {
 	if x != nil {
-		return x.RecordId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *BlacklistRecordOperationRequest) GetClientId() string {
+func (x *CreateListRequest) GetDefaultTtl() *durationpb.Duration {
 	if x != nil {
-		return x.ClientId
+		return x.DefaultTtl
 	}
-	return ""
+	return nil
 }
 
-func (x *BlacklistRecordOperationRequest) GetProductId() string {
+func (x *CreateListRequest) GetMaxBatchSize() int32 {
 	if x != nil {
-		return x.ProductId
+		return x.MaxBatchSize
 	}
-	return ""
+	return 0
 }
 
-type BlacklistBatchRequest struct {
+// Deleting a list deletes every record in it
+type ListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Requests []*BlacklistRecordOperationRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *BlacklistBatchRequest) Reset() {
-	*x = BlacklistBatchRequest{}
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tools_protos_blacklist_proto_msgTypes[3]
+		mi := &file_tools_protos_blacklist_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BlacklistBatchRequest) String() string {
+func (x *ListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlacklistBatchRequest) ProtoMessage() {}
+func (*ListRequest) ProtoMessage() {}
 
-func (x *BlacklistBatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tools_protos_blacklist_proto_msgTypes[3]
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tools_protos_blacklist_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -331,16 +1183,16 @@ func (x *BlacklistBatchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlacklistBatchRequest.ProtoReflect.Descriptor instead.
-func (*BlacklistBatchRequest) Descriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *BlacklistBatchRequest) GetRequests() []*BlacklistRecordOperationRequest {
+func (x *ListRequest) GetName() string {
 	if x != nil {
-		return x.Requests
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
 type BlacklistRecordQueriesRequest struct {
@@ -350,12 +1202,13 @@ type BlacklistRecordQueriesRequest struct {
 
 	Queries        []*BlacklistRecordQueryRequest   `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
 	BetweenQueries []*BlacklistRecordBetweenRequest `protobuf:"bytes,2,rep,name=betweenQueries,proto3" json:"betweenQueries,omitempty"`
+	List           string                           `protobuf:"bytes,3,opt,name=list,proto3" json:"list,omitempty"`
 }
 
 func (x *BlacklistRecordQueriesRequest) Reset() {
 	*x = BlacklistRecordQueriesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tools_protos_blacklist_proto_msgTypes[4]
+		mi := &file_tools_protos_blacklist_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -368,7 +1221,7 @@ func (x *BlacklistRecordQueriesRequest) String() string {
 func (*BlacklistRecordQueriesRequest) ProtoMessage() {}
 
 func (x *BlacklistRecordQueriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tools_protos_blacklist_proto_msgTypes[4]
+	mi := &file_tools_protos_blacklist_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -381,7 +1234,7 @@ func (x *BlacklistRecordQueriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlacklistRecordQueriesRequest.ProtoReflect.Descriptor instead.
 func (*BlacklistRecordQueriesRequest) Descriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{4}
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *BlacklistRecordQueriesRequest) GetQueries() []*BlacklistRecordQueryRequest {
@@ -398,6 +1251,13 @@ func (x *BlacklistRecordQueriesRequest) GetBetweenQueries() []*BlacklistRecordBe
 	return nil
 }
 
+func (x *BlacklistRecordQueriesRequest) GetList() string {
+	if x != nil {
+		return x.List
+	}
+	return ""
+}
+
 type BlacklistRecordBetweenQueriesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -409,7 +1269,7 @@ type BlacklistRecordBetweenQueriesRequest struct {
 func (x *BlacklistRecordBetweenQueriesRequest) Reset() {
 	*x = BlacklistRecordBetweenQueriesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tools_protos_blacklist_proto_msgTypes[5]
+		mi := &file_tools_protos_blacklist_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -422,7 +1282,7 @@ func (x *BlacklistRecordBetweenQueriesRequest) String() string {
 func (*BlacklistRecordBetweenQueriesRequest) ProtoMessage() {}
 
 func (x *BlacklistRecordBetweenQueriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tools_protos_blacklist_proto_msgTypes[5]
+	mi := &file_tools_protos_blacklist_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,7 +1295,7 @@ func (x *BlacklistRecordBetweenQueriesRequest) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use BlacklistRecordBetweenQueriesRequest.ProtoReflect.Descriptor instead.
 func (*BlacklistRecordBetweenQueriesRequest) Descriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{5}
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BlacklistRecordBetweenQueriesRequest) GetQueries() []*BlacklistRecordBetweenRequest {
@@ -458,7 +1318,7 @@ type BlacklistRecordQueryRequest struct {
 func (x *BlacklistRecordQueryRequest) Reset() {
 	*x = BlacklistRecordQueryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tools_protos_blacklist_proto_msgTypes[6]
+		mi := &file_tools_protos_blacklist_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -471,7 +1331,7 @@ func (x *BlacklistRecordQueryRequest) String() string {
 func (*BlacklistRecordQueryRequest) ProtoMessage() {}
 
 func (x *BlacklistRecordQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tools_protos_blacklist_proto_msgTypes[6]
+	mi := &file_tools_protos_blacklist_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -484,7 +1344,7 @@ func (x *BlacklistRecordQueryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlacklistRecordQueryRequest.ProtoReflect.Descriptor instead.
 func (*BlacklistRecordQueryRequest) Descriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{6}
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BlacklistRecordQueryRequest) GetField() SupportedQueryField {
@@ -521,7 +1381,7 @@ type BlacklistRecordBetweenRequest struct {
 func (x *BlacklistRecordBetweenRequest) Reset() {
 	*x = BlacklistRecordBetweenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tools_protos_blacklist_proto_msgTypes[7]
+		mi := &file_tools_protos_blacklist_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -534,7 +1394,7 @@ func (x *BlacklistRecordBetweenRequest) String() string {
 func (*BlacklistRecordBetweenRequest) ProtoMessage() {}
 
 func (x *BlacklistRecordBetweenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tools_protos_blacklist_proto_msgTypes[7]
+	mi := &file_tools_protos_blacklist_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -547,7 +1407,7 @@ func (x *BlacklistRecordBetweenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlacklistRecordBetweenRequest.ProtoReflect.Descriptor instead.
 func (*BlacklistRecordBetweenRequest) Descriptor() ([]byte, []int) {
-	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{7}
+	return file_tools_protos_blacklist_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BlacklistRecordBetweenRequest) GetField() SupportedQueryField {
@@ -575,109 +1435,298 @@ var File_tools_protos_blacklist_proto protoreflect.FileDescriptor
 
 var file_tools_protos_blacklist_proto_rawDesc = []byte{
 	0x0a, 0x1c, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x62,
-	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07,
-	0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x8c, 0x01, 0x0a, 0x12, 0x42, 0x6c, 0x61, 0x63,
-	0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x74, 0x6f, 0x12, 0x1b,
-	0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64,
-	0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72,
-	0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x64, 0x64, 0x65, 0x64,
-	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64,
-	0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x22, 0x7a, 0x0a, 0x1f, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c,
+	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0xf6, 0x04, 0x0a, 0x12, 0x42,
+	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x74,
+	0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x61, 0x64,
+	0x64, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x61, 0x64, 0x64, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x37, 0x0a, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x42,
+	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x74,
+	0x6f, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x1f, 0x0a,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69,
+	0x73, 0x74, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x4a, 0x04, 0x08,
+	0x04, 0x10, 0x05, 0x22, 0xa3, 0x04, 0x0a, 0x1f, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64,
+	0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x6f, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x44, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c,
 	0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
-	0x49, 0x64, 0x22, 0x55, 0x0a, 0x15, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42,
-	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x08, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e,
-	0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
-	0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x9f, 0x01, 0x0a, 0x1d, 0x42, 0x6c,
-	0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65,
-	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x71,
-	0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x42,
-	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72,
-	0x69, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x51, 0x75,
-	0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x42, 0x6c,
-	0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x65, 0x74,
-	0x77, 0x65, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0e, 0x62, 0x65, 0x74,
-	0x77, 0x65, 0x65, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x60, 0x0a, 0x24, 0x42,
-	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x65,
-	0x74, 0x77, 0x65, 0x65, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x97, 0x01,
-	0x0a, 0x1b, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a,
-	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x53,
-	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x69, 0x65,
-	0x6c, 0x64, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x36, 0x0a, 0x09, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x53,
-	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x71, 0x0a, 0x1d, 0x42, 0x6c, 0x61, 0x63, 0x6b,
-	0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72,
-	0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x66,
-	0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x2a, 0x53, 0x0a, 0x13, 0x53, 0x75,
-	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x12, 0x0d, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x10, 0x00,
-	0x12, 0x0d, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x10, 0x01, 0x12,
-	0x0e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x10, 0x02, 0x12,
-	0x0e, 0x0a, 0x0a, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x10, 0x04, 0x2a,
-	0x59, 0x0a, 0x17, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x51,
-	0x55, 0x41, 0x4c, 0x53, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45,
-	0x52, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x45, 0x53, 0x53,
-	0x45, 0x52, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x45, 0x47,
-	0x49, 0x4e, 0x53, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x10, 0x03, 0x32, 0x95, 0x04, 0x0a, 0x09, 0x42,
-	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42,
-	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1d, 0x0a,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a, 0x10,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x63,
+	0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x1a, 0x39,
+	0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x95, 0x01, 0x0a, 0x1c, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x06, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x42, 0x6c, 0x61,
+	0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d,
+	0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73,
+	0x6b, 0x22, 0x69, 0x0a, 0x15, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x08, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x42,
+	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x22, 0xab, 0x01, 0x0a,
+	0x18, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49,
+	0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x49, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x42, 0x6c, 0x61, 0x63,
+	0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x74, 0x6f, 0x52, 0x06,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0xc6, 0x02, 0x0a, 0x14, 0x42,
+	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x62, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x12, 0x2b,
+	0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x44, 0x74, 0x6f, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x64,
+	0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x44, 0x74, 0x6f, 0x52, 0x09, 0x64, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x42, 0x79, 0x12,
+	0x28, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x10, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x22, 0xba, 0x01, 0x0a, 0x07, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x74, 0x6f, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x74,
+	0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x74, 0x6c, 0x12,
+	0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x22, 0x89, 0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x54, 0x74, 0x6c, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x21, 0x0a, 0x0b,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0xb3, 0x01, 0x0a, 0x1d, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x36, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x62, 0x65, 0x74,
+	0x77, 0x65, 0x65, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x0e, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x60, 0x0a, 0x24, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x51,
+	0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a,
+	0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x42, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07,
+	0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x1b, 0x42, 0x6c, 0x61, 0x63,
+	0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x12, 0x36, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x71, 0x0a, 0x1d, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x6e,
+	0x69, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x2a, 0x22, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4b, 0x69,
+	0x6e, 0x64, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x00, 0x12, 0x09, 0x0a,
+	0x05, 0x41, 0x4c, 0x4c, 0x4f, 0x57, 0x10, 0x01, 0x2a, 0x58, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10,
+	0x00, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x4c, 0x4c, 0x5f, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43, 0x54,
+	0x53, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x4c, 0x4c, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e,
+	0x54, 0x53, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x4c, 0x4c, 0x5f, 0x43, 0x4c, 0x49, 0x45,
+	0x4e, 0x54, 0x53, 0x5f, 0x41, 0x4e, 0x44, 0x5f, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43, 0x54, 0x53,
+	0x10, 0x03, 0x2a, 0x31, 0x0a, 0x08, 0x53, 0x61, 0x76, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0a,
+	0x0a, 0x06, 0x55, 0x50, 0x53, 0x45, 0x52, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52,
+	0x45, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x56, 0x45, 0x52, 0x57, 0x52,
+	0x49, 0x54, 0x45, 0x10, 0x02, 0x2a, 0x41, 0x0a, 0x0f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74,
+	0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00,
+	0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x12,
+	0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06,
+	0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x70, 0x0a, 0x13, 0x53, 0x75, 0x70, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12,
+	0x0d, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x10, 0x00, 0x12, 0x0d,
+	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x10, 0x01, 0x12, 0x0e, 0x0a,
+	0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x10, 0x02, 0x12, 0x0e, 0x0a,
+	0x0a, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x10, 0x04, 0x12, 0x0f, 0x0a,
+	0x0b, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x10, 0x05, 0x12, 0x0a,
+	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x10, 0x06, 0x2a, 0x59, 0x0a, 0x17, 0x53, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x53, 0x10,
+	0x00, 0x12, 0x10, 0x0a, 0x0c, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x5f, 0x54, 0x48, 0x41,
+	0x4e, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x45, 0x53, 0x53, 0x45, 0x52, 0x5f, 0x54, 0x48,
+	0x41, 0x4e, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x45, 0x47, 0x49, 0x4e, 0x53, 0x5f, 0x57,
+	0x49, 0x54, 0x48, 0x10, 0x03, 0x32, 0xb9, 0x09, 0x0a, 0x09, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c,
+	0x69, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x42, 0x6c, 0x61, 0x63,
+	0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x42, 0x6c,
+	0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x74, 0x6f,
+	0x12, 0x50, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x2e, 0x42, 0x6c,
+	0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x28, 0x01,
+	0x30, 0x01, 0x12, 0x51, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1e,
+	0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
 	0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x13, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x44, 0x74, 0x6f, 0x12, 0x4a, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x61, 0x63,
-	0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68,
-	0x12, 0x16, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b,
-	0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x74, 0x6f, 0x28, 0x01, 0x30,
-	0x01, 0x12, 0x51, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73,
-	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1e, 0x2e,
-	0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51,
-	0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
-	0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44,
-	0x74, 0x6f, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x13, 0x53, 0x61, 0x76, 0x65, 0x42, 0x6c, 0x61, 0x63,
-	0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x42, 0x6c,
-	0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
-	0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44,
-	0x74, 0x6f, 0x12, 0x4b, 0x0a, 0x18, 0x53, 0x61, 0x76, 0x65, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c,
-	0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16,
-	0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69,
-	0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x74, 0x6f, 0x28, 0x01, 0x30, 0x01, 0x12,
-	0x41, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69,
-	0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b,
-	0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x12, 0x3e, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x12, 0x16, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x28, 0x01, 0x42, 0x13, 0x5a, 0x11, 0x7a, 0x6f, 0x72, 0x72, 0x65, 0x72, 0x6f, 0x2f, 0x62, 0x6c,
-	0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x44, 0x74, 0x6f, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x13, 0x53, 0x61, 0x76, 0x65, 0x42, 0x6c, 0x61,
+	0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x42,
+	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x44, 0x74, 0x6f, 0x12, 0x51, 0x0a, 0x18, 0x53, 0x61, 0x76, 0x65, 0x42, 0x6c, 0x61, 0x63, 0x6b,
+	0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x16, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c,
+	0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4b, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x1d, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x44, 0x74, 0x6f, 0x12, 0x41, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x6c, 0x61,
+	0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x42,
+	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x06,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x53, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x42,
+	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65,
+	0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4b, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x12, 0x20, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x44, 0x74, 0x6f, 0x12, 0x4c, 0x0a, 0x13, 0x53, 0x61, 0x76, 0x65,
+	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x20, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x13, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x44, 0x74, 0x6f, 0x12, 0x4b, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x1d, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x44, 0x74, 0x6f, 0x12, 0x41, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6c, 0x6c,
+	0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x42,
+	0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x06,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x48, 0x0a, 0x0d, 0x49, 0x73, 0x42, 0x6c, 0x61, 0x63,
+	0x6b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x12, 0x20, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x42, 0x6c, 0x61, 0x63,
+	0x6b, 0x6c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x47, 0x0a, 0x12, 0x49, 0x73, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69,
+	0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x28, 0x01, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x0a, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x12, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x74, 0x6f, 0x12, 0x26, 0x0a, 0x0c, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x74, 0x6f, 0x12, 0x22, 0x0a,
+	0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x42, 0x13, 0x5a, 0x11, 0x7a, 0x6f, 0x72, 0x72, 0x65, 0x72, 0x6f, 0x2f, 0x62, 0x6c, 0x61,
+	0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -692,47 +1741,102 @@ func file_tools_protos_blacklist_proto_rawDescGZIP() []byte {
 	return file_tools_protos_blacklist_proto_rawDescData
 }
 
-var file_tools_protos_blacklist_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_tools_protos_blacklist_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_tools_protos_blacklist_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_tools_protos_blacklist_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_tools_protos_blacklist_proto_goTypes = []interface{}{
-	(SupportedQueryField)(0),                     // 0: SupportedQueryField
-	(SupportedQueryOperation)(0),                 // 1: SupportedQueryOperation
-	(*Empty)(nil),                                // 2: Empty
-	(*BlacklistRecordDto)(nil),                   // 3: BlacklistRecordDto
-	(*BlacklistRecordOperationRequest)(nil),      // 4: BlacklistRecordOperationRequest
-	(*BlacklistBatchRequest)(nil),                // 5: BlacklistBatchRequest
-	(*BlacklistRecordQueriesRequest)(nil),        // 6: BlacklistRecordQueriesRequest
-	(*BlacklistRecordBetweenQueriesRequest)(nil), // 7: BlacklistRecordBetweenQueriesRequest
-	(*BlacklistRecordQueryRequest)(nil),          // 8: BlacklistRecordQueryRequest
-	(*BlacklistRecordBetweenRequest)(nil),        // 9: BlacklistRecordBetweenRequest
+	(RecordKind)(0),                              // 0: RecordKind
+	(BlockScope)(0),                              // 1: BlockScope
+	(SaveMode)(0),                                // 2: SaveMode
+	(BatchItemStatus)(0),                         // 3: BatchItemStatus
+	(SupportedQueryField)(0),                     // 4: SupportedQueryField
+	(SupportedQueryOperation)(0),                 // 5: SupportedQueryOperation
+	(*Empty)(nil),                                // 6: Empty
+	(*BlacklistRecordDto)(nil),                   // 7: BlacklistRecordDto
+	(*BlacklistRecordOperationRequest)(nil),      // 8: BlacklistRecordOperationRequest
+	(*UpdateBlacklistRecordRequest)(nil),         // 9: UpdateBlacklistRecordRequest
+	(*BlacklistBatchRequest)(nil),                // 10: BlacklistBatchRequest
+	(*BlacklistBatchItemResult)(nil),             // 11: BlacklistBatchItemResult
+	(*BlacklistCheckResult)(nil),                 // 12: BlacklistCheckResult
+	(*ListDto)(nil),                              // 13: ListDto
+	(*CreateListRequest)(nil),                    // 14: CreateListRequest
+	(*ListRequest)(nil),                          // 15: ListRequest
+	(*BlacklistRecordQueriesRequest)(nil),        // 16: BlacklistRecordQueriesRequest
+	(*BlacklistRecordBetweenQueriesRequest)(nil), // 17: BlacklistRecordBetweenQueriesRequest
+	(*BlacklistRecordQueryRequest)(nil),          // 18: BlacklistRecordQueryRequest
+	(*BlacklistRecordBetweenRequest)(nil),        // 19: BlacklistRecordBetweenRequest
+	nil,                                          // 20: BlacklistRecordDto.LabelsEntry
+	nil,                                          // 21: BlacklistRecordOperationRequest.LabelsEntry
+	(*timestamppb.Timestamp)(nil),                // 22: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),                // 23: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),                  // 24: google.protobuf.Duration
 }
 var file_tools_protos_blacklist_proto_depIdxs = []int32{
-	4,  // 0: BlacklistBatchRequest.requests:type_name -> BlacklistRecordOperationRequest
-	8,  // 1: BlacklistRecordQueriesRequest.queries:type_name -> BlacklistRecordQueryRequest
-	9,  // 2: BlacklistRecordQueriesRequest.betweenQueries:type_name -> BlacklistRecordBetweenRequest
-	9,  // 3: BlacklistRecordBetweenQueriesRequest.queries:type_name -> BlacklistRecordBetweenRequest
-	0,  // 4: BlacklistRecordQueryRequest.field:type_name -> SupportedQueryField
-	1,  // 5: BlacklistRecordQueryRequest.operation:type_name -> SupportedQueryOperation
-	0,  // 6: BlacklistRecordBetweenRequest.field:type_name -> SupportedQueryField
-	4,  // 7: Blacklist.GetBlacklistRecord:input_type -> BlacklistRecordOperationRequest
-	5,  // 8: Blacklist.GetBlacklistRecordBatch:input_type -> BlacklistBatchRequest
-	6,  // 9: Blacklist.GetBlacklistRecordsQuery:input_type -> BlacklistRecordQueriesRequest
-	4,  // 10: Blacklist.SaveBlacklistRecord:input_type -> BlacklistRecordOperationRequest
-	5,  // 11: Blacklist.SaveBlacklistRecordBatch:input_type -> BlacklistBatchRequest
-	4,  // 12: Blacklist.DeleteBlacklistRecord:input_type -> BlacklistRecordOperationRequest
-	5,  // 13: Blacklist.DeleteBatchBlacklistRecord:input_type -> BlacklistBatchRequest
-	3,  // 14: Blacklist.GetBlacklistRecord:output_type -> BlacklistRecordDto
-	3,  // 15: Blacklist.GetBlacklistRecordBatch:output_type -> BlacklistRecordDto
-	3,  // 16: Blacklist.GetBlacklistRecordsQuery:output_type -> BlacklistRecordDto
-	3,  // 17: Blacklist.SaveBlacklistRecord:output_type -> BlacklistRecordDto
-	3,  // 18: Blacklist.SaveBlacklistRecordBatch:output_type -> BlacklistRecordDto
-	2,  // 19: Blacklist.DeleteBlacklistRecord:output_type -> Empty
-	2,  // 20: Blacklist.DeleteBatchBlacklistRecord:output_type -> Empty
-	14, // [14:21] is the sub-list for method output_type
-	7,  // [7:14] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	22, // 0: BlacklistRecordDto.added_date:type_name -> google.protobuf.Timestamp
+	22, // 1: BlacklistRecordDto.expires_at:type_name -> google.protobuf.Timestamp
+	20, // 2: BlacklistRecordDto.labels:type_name -> BlacklistRecordDto.LabelsEntry
+	22, // 3: BlacklistRecordDto.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 4: BlacklistRecordDto.scope:type_name -> BlockScope
+	0,  // 5: BlacklistRecordDto.kind:type_name -> RecordKind
+	22, // 6: BlacklistRecordOperationRequest.expires_at:type_name -> google.protobuf.Timestamp
+	21, // 7: BlacklistRecordOperationRequest.labels:type_name -> BlacklistRecordOperationRequest.LabelsEntry
+	2,  // 8: BlacklistRecordOperationRequest.mode:type_name -> SaveMode
+	1,  // 9: BlacklistRecordOperationRequest.scope:type_name -> BlockScope
+	8,  // 10: UpdateBlacklistRecordRequest.record:type_name -> BlacklistRecordOperationRequest
+	23, // 11: UpdateBlacklistRecordRequest.update_mask:type_name -> google.protobuf.FieldMask
+	8,  // 12: BlacklistBatchRequest.requests:type_name -> BlacklistRecordOperationRequest
+	3,  // 13: BlacklistBatchItemResult.status:type_name -> BatchItemStatus
+	7,  // 14: BlacklistBatchItemResult.record:type_name -> BlacklistRecordDto
+	7,  // 15: BlacklistCheckResult.record:type_name -> BlacklistRecordDto
+	7,  // 16: BlacklistCheckResult.decided_by:type_name -> BlacklistRecordDto
+	3,  // 17: BlacklistCheckResult.status:type_name -> BatchItemStatus
+	24, // 18: ListDto.default_ttl:type_name -> google.protobuf.Duration
+	22, // 19: ListDto.created_at:type_name -> google.protobuf.Timestamp
+	24, // 20: CreateListRequest.default_ttl:type_name -> google.protobuf.Duration
+	18, // 21: BlacklistRecordQueriesRequest.queries:type_name -> BlacklistRecordQueryRequest
+	19, // 22: BlacklistRecordQueriesRequest.betweenQueries:type_name -> BlacklistRecordBetweenRequest
+	19, // 23: BlacklistRecordBetweenQueriesRequest.queries:type_name -> BlacklistRecordBetweenRequest
+	4,  // 24: BlacklistRecordQueryRequest.field:type_name -> SupportedQueryField
+	5,  // 25: BlacklistRecordQueryRequest.operation:type_name -> SupportedQueryOperation
+	4,  // 26: BlacklistRecordBetweenRequest.field:type_name -> SupportedQueryField
+	8,  // 27: Blacklist.GetBlacklistRecord:input_type -> BlacklistRecordOperationRequest
+	10, // 28: Blacklist.GetBlacklistRecordBatch:input_type -> BlacklistBatchRequest
+	16, // 29: Blacklist.GetBlacklistRecordsQuery:input_type -> BlacklistRecordQueriesRequest
+	8,  // 30: Blacklist.SaveBlacklistRecord:input_type -> BlacklistRecordOperationRequest
+	10, // 31: Blacklist.SaveBlacklistRecordBatch:input_type -> BlacklistBatchRequest
+	9,  // 32: Blacklist.UpdateBlacklistRecord:input_type -> UpdateBlacklistRecordRequest
+	8,  // 33: Blacklist.DeleteBlacklistRecord:input_type -> BlacklistRecordOperationRequest
+	10, // 34: Blacklist.DeleteBatchBlacklistRecord:input_type -> BlacklistBatchRequest
+	8,  // 35: Blacklist.GetAllowlistRecord:input_type -> BlacklistRecordOperationRequest
+	8,  // 36: Blacklist.SaveAllowlistRecord:input_type -> BlacklistRecordOperationRequest
+	9,  // 37: Blacklist.UpdateAllowlistRecord:input_type -> UpdateBlacklistRecordRequest
+	8,  // 38: Blacklist.DeleteAllowlistRecord:input_type -> BlacklistRecordOperationRequest
+	8,  // 39: Blacklist.IsBlacklisted:input_type -> BlacklistRecordOperationRequest
+	10, // 40: Blacklist.IsBlacklistedBatch:input_type -> BlacklistBatchRequest
+	14, // 41: Blacklist.CreateList:input_type -> CreateListRequest
+	15, // 42: Blacklist.DescribeList:input_type -> ListRequest
+	15, // 43: Blacklist.DeleteList:input_type -> ListRequest
+	7,  // 44: Blacklist.GetBlacklistRecord:output_type -> BlacklistRecordDto
+	11, // 45: Blacklist.GetBlacklistRecordBatch:output_type -> BlacklistBatchItemResult
+	7,  // 46: Blacklist.GetBlacklistRecordsQuery:output_type -> BlacklistRecordDto
+	7,  // 47: Blacklist.SaveBlacklistRecord:output_type -> BlacklistRecordDto
+	11, // 48: Blacklist.SaveBlacklistRecordBatch:output_type -> BlacklistBatchItemResult
+	7,  // 49: Blacklist.UpdateBlacklistRecord:output_type -> BlacklistRecordDto
+	6,  // 50: Blacklist.DeleteBlacklistRecord:output_type -> Empty
+	11, // 51: Blacklist.DeleteBatchBlacklistRecord:output_type -> BlacklistBatchItemResult
+	7,  // 52: Blacklist.GetAllowlistRecord:output_type -> BlacklistRecordDto
+	7,  // 53: Blacklist.SaveAllowlistRecord:output_type -> BlacklistRecordDto
+	7,  // 54: Blacklist.UpdateAllowlistRecord:output_type -> BlacklistRecordDto
+	6,  // 55: Blacklist.DeleteAllowlistRecord:output_type -> Empty
+	12, // 56: Blacklist.IsBlacklisted:output_type -> BlacklistCheckResult
+	12, // 57: Blacklist.IsBlacklistedBatch:output_type -> BlacklistCheckResult
+	13, // 58: Blacklist.CreateList:output_type -> ListDto
+	13, // 59: Blacklist.DescribeList:output_type -> ListDto
+	6,  // 60: Blacklist.DeleteList:output_type -> Empty
+	44, // [44:61] is the sub-list for method output_type
+	27, // [27:44] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_tools_protos_blacklist_proto_init() }
@@ -778,7 +1882,7 @@ func file_tools_protos_blacklist_proto_init() {
 			}
 		}
 		file_tools_protos_blacklist_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlacklistBatchRequest); i {
+			switch v := v.(*UpdateBlacklistRecordRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -790,7 +1894,7 @@ func file_tools_protos_blacklist_proto_init() {
 			}
 		}
 		file_tools_protos_blacklist_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlacklistRecordQueriesRequest); i {
+			switch v := v.(*BlacklistBatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -802,7 +1906,7 @@ func file_tools_protos_blacklist_proto_init() {
 			}
 		}
 		file_tools_protos_blacklist_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlacklistRecordBetweenQueriesRequest); i {
+			switch v := v.(*BlacklistBatchItemResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -814,7 +1918,7 @@ func file_tools_protos_blacklist_proto_init() {
 			}
 		}
 		file_tools_protos_blacklist_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlacklistRecordQueryRequest); i {
+			switch v := v.(*BlacklistCheckResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -826,6 +1930,78 @@ func file_tools_protos_blacklist_proto_init() {
 			}
 		}
 		file_tools_protos_blacklist_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tools_protos_blacklist_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tools_protos_blacklist_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tools_protos_blacklist_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlacklistRecordQueriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tools_protos_blacklist_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlacklistRecordBetweenQueriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tools_protos_blacklist_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlacklistRecordQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tools_protos_blacklist_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BlacklistRecordBetweenRequest); i {
 			case 0:
 				return &v.state
@@ -843,8 +2019,8 @@ func file_tools_protos_blacklist_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_tools_protos_blacklist_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   8,
+			NumEnums:      6,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,8 +27,18 @@ type BlacklistClient interface {
 	GetBlacklistRecordsQuery(ctx context.Context, in *BlacklistRecordQueriesRequest, opts ...grpc.CallOption) (Blacklist_GetBlacklistRecordsQueryClient, error)
 	SaveBlacklistRecord(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*BlacklistRecordDto, error)
 	SaveBlacklistRecordBatch(ctx context.Context, opts ...grpc.CallOption) (Blacklist_SaveBlacklistRecordBatchClient, error)
+	UpdateBlacklistRecord(ctx context.Context, in *UpdateBlacklistRecordRequest, opts ...grpc.CallOption) (*BlacklistRecordDto, error)
 	DeleteBlacklistRecord(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*Empty, error)
 	DeleteBatchBlacklistRecord(ctx context.Context, opts ...grpc.CallOption) (Blacklist_DeleteBatchBlacklistRecordClient, error)
+	GetAllowlistRecord(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*BlacklistRecordDto, error)
+	SaveAllowlistRecord(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*BlacklistRecordDto, error)
+	UpdateAllowlistRecord(ctx context.Context, in *UpdateBlacklistRecordRequest, opts ...grpc.CallOption) (*BlacklistRecordDto, error)
+	DeleteAllowlistRecord(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*Empty, error)
+	IsBlacklisted(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*BlacklistCheckResult, error)
+	IsBlacklistedBatch(ctx context.Context, opts ...grpc.CallOption) (Blacklist_IsBlacklistedBatchClient, error)
+	CreateList(ctx context.Context, in *CreateListRequest, opts ...grpc.CallOption) (*ListDto, error)
+	DescribeList(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListDto, error)
+	DeleteList(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type blacklistClient struct {
@@ -59,7 +69,7 @@ func (c *blacklistClient) GetBlacklistRecordBatch(ctx context.Context, opts ...g
 
 type Blacklist_GetBlacklistRecordBatchClient interface {
 	Send(*BlacklistBatchRequest) error
-	Recv() (*BlacklistRecordDto, error)
+	Recv() (*BlacklistBatchItemResult, error)
 	grpc.ClientStream
 }
 
@@ -71,8 +81,8 @@ func (x *blacklistGetBlacklistRecordBatchClient) Send(m *BlacklistBatchRequest)
 	return x.ClientStream.SendMsg(m)
 }
 
-func (x *blacklistGetBlacklistRecordBatchClient) Recv() (*BlacklistRecordDto, error) {
-	m := new(BlacklistRecordDto)
+func (x *blacklistGetBlacklistRecordBatchClient) Recv() (*BlacklistBatchItemResult, error) {
+	m := new(BlacklistBatchItemResult)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -131,7 +141,7 @@ func (c *blacklistClient) SaveBlacklistRecordBatch(ctx context.Context, opts ...
 
 type Blacklist_SaveBlacklistRecordBatchClient interface {
 	Send(*BlacklistBatchRequest) error
-	Recv() (*BlacklistRecordDto, error)
+	Recv() (*BlacklistBatchItemResult, error)
 	grpc.ClientStream
 }
 
@@ -143,14 +153,23 @@ func (x *blacklistSaveBlacklistRecordBatchClient) Send(m *BlacklistBatchRequest)
 	return x.ClientStream.SendMsg(m)
 }
 
-func (x *blacklistSaveBlacklistRecordBatchClient) Recv() (*BlacklistRecordDto, error) {
-	m := new(BlacklistRecordDto)
+func (x *blacklistSaveBlacklistRecordBatchClient) Recv() (*BlacklistBatchItemResult, error) {
+	m := new(BlacklistBatchItemResult)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+func (c *blacklistClient) UpdateBlacklistRecord(ctx context.Context, in *UpdateBlacklistRecordRequest, opts ...grpc.CallOption) (*BlacklistRecordDto, error) {
+	out := new(BlacklistRecordDto)
+	err := c.cc.Invoke(ctx, "/Blacklist/UpdateBlacklistRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blacklistClient) DeleteBlacklistRecord(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/Blacklist/DeleteBlacklistRecord", in, out, opts...)
@@ -171,7 +190,7 @@ func (c *blacklistClient) DeleteBatchBlacklistRecord(ctx context.Context, opts .
 
 type Blacklist_DeleteBatchBlacklistRecordClient interface {
 	Send(*BlacklistBatchRequest) error
-	CloseAndRecv() (*Empty, error)
+	Recv() (*BlacklistBatchItemResult, error)
 	grpc.ClientStream
 }
 
@@ -183,17 +202,117 @@ func (x *blacklistDeleteBatchBlacklistRecordClient) Send(m *BlacklistBatchReques
 	return x.ClientStream.SendMsg(m)
 }
 
-func (x *blacklistDeleteBatchBlacklistRecordClient) CloseAndRecv() (*Empty, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
+func (x *blacklistDeleteBatchBlacklistRecordClient) Recv() (*BlacklistBatchItemResult, error) {
+	m := new(BlacklistBatchItemResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	m := new(Empty)
+	return m, nil
+}
+
+func (c *blacklistClient) GetAllowlistRecord(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*BlacklistRecordDto, error) {
+	out := new(BlacklistRecordDto)
+	err := c.cc.Invoke(ctx, "/Blacklist/GetAllowlistRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blacklistClient) SaveAllowlistRecord(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*BlacklistRecordDto, error) {
+	out := new(BlacklistRecordDto)
+	err := c.cc.Invoke(ctx, "/Blacklist/SaveAllowlistRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blacklistClient) UpdateAllowlistRecord(ctx context.Context, in *UpdateBlacklistRecordRequest, opts ...grpc.CallOption) (*BlacklistRecordDto, error) {
+	out := new(BlacklistRecordDto)
+	err := c.cc.Invoke(ctx, "/Blacklist/UpdateAllowlistRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blacklistClient) DeleteAllowlistRecord(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/Blacklist/DeleteAllowlistRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blacklistClient) IsBlacklisted(ctx context.Context, in *BlacklistRecordOperationRequest, opts ...grpc.CallOption) (*BlacklistCheckResult, error) {
+	out := new(BlacklistCheckResult)
+	err := c.cc.Invoke(ctx, "/Blacklist/IsBlacklisted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blacklistClient) IsBlacklistedBatch(ctx context.Context, opts ...grpc.CallOption) (Blacklist_IsBlacklistedBatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Blacklist_ServiceDesc.Streams[4], "/Blacklist/IsBlacklistedBatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blacklistIsBlacklistedBatchClient{stream}
+	return x, nil
+}
+
+type Blacklist_IsBlacklistedBatchClient interface {
+	Send(*BlacklistBatchRequest) error
+	Recv() (*BlacklistCheckResult, error)
+	grpc.ClientStream
+}
+
+type blacklistIsBlacklistedBatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *blacklistIsBlacklistedBatchClient) Send(m *BlacklistBatchRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *blacklistIsBlacklistedBatchClient) Recv() (*BlacklistCheckResult, error) {
+	m := new(BlacklistCheckResult)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+func (c *blacklistClient) CreateList(ctx context.Context, in *CreateListRequest, opts ...grpc.CallOption) (*ListDto, error) {
+	out := new(ListDto)
+	err := c.cc.Invoke(ctx, "/Blacklist/CreateList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blacklistClient) DescribeList(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListDto, error) {
+	out := new(ListDto)
+	err := c.cc.Invoke(ctx, "/Blacklist/DescribeList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blacklistClient) DeleteList(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/Blacklist/DeleteList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlacklistServer is the server API for Blacklist service.
 // All implementations must embed UnimplementedBlacklistServer
 // for forward compatibility
@@ -203,8 +322,18 @@ type BlacklistServer interface {
 	GetBlacklistRecordsQuery(*BlacklistRecordQueriesRequest, Blacklist_GetBlacklistRecordsQueryServer) error
 	SaveBlacklistRecord(context.Context, *BlacklistRecordOperationRequest) (*BlacklistRecordDto, error)
 	SaveBlacklistRecordBatch(Blacklist_SaveBlacklistRecordBatchServer) error
+	UpdateBlacklistRecord(context.Context, *UpdateBlacklistRecordRequest) (*BlacklistRecordDto, error)
 	DeleteBlacklistRecord(context.Context, *BlacklistRecordOperationRequest) (*Empty, error)
 	DeleteBatchBlacklistRecord(Blacklist_DeleteBatchBlacklistRecordServer) error
+	GetAllowlistRecord(context.Context, *BlacklistRecordOperationRequest) (*BlacklistRecordDto, error)
+	SaveAllowlistRecord(context.Context, *BlacklistRecordOperationRequest) (*BlacklistRecordDto, error)
+	UpdateAllowlistRecord(context.Context, *UpdateBlacklistRecordRequest) (*BlacklistRecordDto, error)
+	DeleteAllowlistRecord(context.Context, *BlacklistRecordOperationRequest) (*Empty, error)
+	IsBlacklisted(context.Context, *BlacklistRecordOperationRequest) (*BlacklistCheckResult, error)
+	IsBlacklistedBatch(Blacklist_IsBlacklistedBatchServer) error
+	CreateList(context.Context, *CreateListRequest) (*ListDto, error)
+	DescribeList(context.Context, *ListRequest) (*ListDto, error)
+	DeleteList(context.Context, *ListRequest) (*Empty, error)
 	mustEmbedUnimplementedBlacklistServer()
 }
 
@@ -227,12 +356,42 @@ func (UnimplementedBlacklistServer) SaveBlacklistRecord(context.Context, *Blackl
 func (UnimplementedBlacklistServer) SaveBlacklistRecordBatch(Blacklist_SaveBlacklistRecordBatchServer) error {
 	return status.Errorf(codes.Unimplemented, "method SaveBlacklistRecordBatch not implemented")
 }
+func (UnimplementedBlacklistServer) UpdateBlacklistRecord(context.Context, *UpdateBlacklistRecordRequest) (*BlacklistRecordDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBlacklistRecord not implemented")
+}
 func (UnimplementedBlacklistServer) DeleteBlacklistRecord(context.Context, *BlacklistRecordOperationRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBlacklistRecord not implemented")
 }
 func (UnimplementedBlacklistServer) DeleteBatchBlacklistRecord(Blacklist_DeleteBatchBlacklistRecordServer) error {
 	return status.Errorf(codes.Unimplemented, "method DeleteBatchBlacklistRecord not implemented")
 }
+func (UnimplementedBlacklistServer) GetAllowlistRecord(context.Context, *BlacklistRecordOperationRequest) (*BlacklistRecordDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllowlistRecord not implemented")
+}
+func (UnimplementedBlacklistServer) SaveAllowlistRecord(context.Context, *BlacklistRecordOperationRequest) (*BlacklistRecordDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveAllowlistRecord not implemented")
+}
+func (UnimplementedBlacklistServer) UpdateAllowlistRecord(context.Context, *UpdateBlacklistRecordRequest) (*BlacklistRecordDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAllowlistRecord not implemented")
+}
+func (UnimplementedBlacklistServer) DeleteAllowlistRecord(context.Context, *BlacklistRecordOperationRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAllowlistRecord not implemented")
+}
+func (UnimplementedBlacklistServer) IsBlacklisted(context.Context, *BlacklistRecordOperationRequest) (*BlacklistCheckResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsBlacklisted not implemented")
+}
+func (UnimplementedBlacklistServer) IsBlacklistedBatch(Blacklist_IsBlacklistedBatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method IsBlacklistedBatch not implemented")
+}
+func (UnimplementedBlacklistServer) CreateList(context.Context, *CreateListRequest) (*ListDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateList not implemented")
+}
+func (UnimplementedBlacklistServer) DescribeList(context.Context, *ListRequest) (*ListDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeList not implemented")
+}
+func (UnimplementedBlacklistServer) DeleteList(context.Context, *ListRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteList not implemented")
+}
 func (UnimplementedBlacklistServer) mustEmbedUnimplementedBlacklistServer() {}
 
 // UnsafeBlacklistServer may be embedded to opt out of forward compatibility for this service.
@@ -269,7 +428,7 @@ func _Blacklist_GetBlacklistRecordBatch_Handler(srv interface{}, stream grpc.Ser
 }
 
 type Blacklist_GetBlacklistRecordBatchServer interface {
-	Send(*BlacklistRecordDto) error
+	Send(*BlacklistBatchItemResult) error
 	Recv() (*BlacklistBatchRequest, error)
 	grpc.ServerStream
 }
@@ -278,7 +437,7 @@ type blacklistGetBlacklistRecordBatchServer struct {
 	grpc.ServerStream
 }
 
-func (x *blacklistGetBlacklistRecordBatchServer) Send(m *BlacklistRecordDto) error {
+func (x *blacklistGetBlacklistRecordBatchServer) Send(m *BlacklistBatchItemResult) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -334,7 +493,7 @@ func _Blacklist_SaveBlacklistRecordBatch_Handler(srv interface{}, stream grpc.Se
 }
 
 type Blacklist_SaveBlacklistRecordBatchServer interface {
-	Send(*BlacklistRecordDto) error
+	Send(*BlacklistBatchItemResult) error
 	Recv() (*BlacklistBatchRequest, error)
 	grpc.ServerStream
 }
@@ -343,7 +502,7 @@ type blacklistSaveBlacklistRecordBatchServer struct {
 	grpc.ServerStream
 }
 
-func (x *blacklistSaveBlacklistRecordBatchServer) Send(m *BlacklistRecordDto) error {
+func (x *blacklistSaveBlacklistRecordBatchServer) Send(m *BlacklistBatchItemResult) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -355,6 +514,24 @@ func (x *blacklistSaveBlacklistRecordBatchServer) Recv() (*BlacklistBatchRequest
 	return m, nil
 }
 
+func _Blacklist_UpdateBlacklistRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBlacklistRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlacklistServer).UpdateBlacklistRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Blacklist/UpdateBlacklistRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlacklistServer).UpdateBlacklistRecord(ctx, req.(*UpdateBlacklistRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Blacklist_DeleteBlacklistRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlacklistRecordOperationRequest)
 	if err := dec(in); err != nil {
@@ -378,7 +555,7 @@ func _Blacklist_DeleteBatchBlacklistRecord_Handler(srv interface{}, stream grpc.
 }
 
 type Blacklist_DeleteBatchBlacklistRecordServer interface {
-	SendAndClose(*Empty) error
+	Send(*BlacklistBatchItemResult) error
 	Recv() (*BlacklistBatchRequest, error)
 	grpc.ServerStream
 }
@@ -387,7 +564,7 @@ type blacklistDeleteBatchBlacklistRecordServer struct {
 	grpc.ServerStream
 }
 
-func (x *blacklistDeleteBatchBlacklistRecordServer) SendAndClose(m *Empty) error {
+func (x *blacklistDeleteBatchBlacklistRecordServer) Send(m *BlacklistBatchItemResult) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -399,6 +576,176 @@ func (x *blacklistDeleteBatchBlacklistRecordServer) Recv() (*BlacklistBatchReque
 	return m, nil
 }
 
+func _Blacklist_GetAllowlistRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlacklistRecordOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlacklistServer).GetAllowlistRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Blacklist/GetAllowlistRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlacklistServer).GetAllowlistRecord(ctx, req.(*BlacklistRecordOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blacklist_SaveAllowlistRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlacklistRecordOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlacklistServer).SaveAllowlistRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Blacklist/SaveAllowlistRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlacklistServer).SaveAllowlistRecord(ctx, req.(*BlacklistRecordOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blacklist_UpdateAllowlistRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBlacklistRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlacklistServer).UpdateAllowlistRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Blacklist/UpdateAllowlistRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlacklistServer).UpdateAllowlistRecord(ctx, req.(*UpdateBlacklistRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blacklist_DeleteAllowlistRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlacklistRecordOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlacklistServer).DeleteAllowlistRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Blacklist/DeleteAllowlistRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlacklistServer).DeleteAllowlistRecord(ctx, req.(*BlacklistRecordOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blacklist_IsBlacklisted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlacklistRecordOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlacklistServer).IsBlacklisted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Blacklist/IsBlacklisted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlacklistServer).IsBlacklisted(ctx, req.(*BlacklistRecordOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blacklist_IsBlacklistedBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlacklistServer).IsBlacklistedBatch(&blacklistIsBlacklistedBatchServer{stream})
+}
+
+type Blacklist_IsBlacklistedBatchServer interface {
+	Send(*BlacklistCheckResult) error
+	Recv() (*BlacklistBatchRequest, error)
+	grpc.ServerStream
+}
+
+type blacklistIsBlacklistedBatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *blacklistIsBlacklistedBatchServer) Send(m *BlacklistCheckResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *blacklistIsBlacklistedBatchServer) Recv() (*BlacklistBatchRequest, error) {
+	m := new(BlacklistBatchRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Blacklist_CreateList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlacklistServer).CreateList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Blacklist/CreateList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlacklistServer).CreateList(ctx, req.(*CreateListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blacklist_DescribeList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlacklistServer).DescribeList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Blacklist/DescribeList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlacklistServer).DescribeList(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blacklist_DeleteList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlacklistServer).DeleteList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Blacklist/DeleteList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlacklistServer).DeleteList(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Blacklist_ServiceDesc is the grpc.ServiceDesc for Blacklist service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -414,10 +761,46 @@ var Blacklist_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SaveBlacklistRecord",
 			Handler:    _Blacklist_SaveBlacklistRecord_Handler,
 		},
+		{
+			MethodName: "UpdateBlacklistRecord",
+			Handler:    _Blacklist_UpdateBlacklistRecord_Handler,
+		},
 		{
 			MethodName: "DeleteBlacklistRecord",
 			Handler:    _Blacklist_DeleteBlacklistRecord_Handler,
 		},
+		{
+			MethodName: "GetAllowlistRecord",
+			Handler:    _Blacklist_GetAllowlistRecord_Handler,
+		},
+		{
+			MethodName: "SaveAllowlistRecord",
+			Handler:    _Blacklist_SaveAllowlistRecord_Handler,
+		},
+		{
+			MethodName: "UpdateAllowlistRecord",
+			Handler:    _Blacklist_UpdateAllowlistRecord_Handler,
+		},
+		{
+			MethodName: "DeleteAllowlistRecord",
+			Handler:    _Blacklist_DeleteAllowlistRecord_Handler,
+		},
+		{
+			MethodName: "IsBlacklisted",
+			Handler:    _Blacklist_IsBlacklisted_Handler,
+		},
+		{
+			MethodName: "CreateList",
+			Handler:    _Blacklist_CreateList_Handler,
+		},
+		{
+			MethodName: "DescribeList",
+			Handler:    _Blacklist_DescribeList_Handler,
+		},
+		{
+			MethodName: "DeleteList",
+			Handler:    _Blacklist_DeleteList_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -440,6 +823,13 @@ var Blacklist_ServiceDesc = grpc.ServiceDesc{
 		{
 			StreamName:    "DeleteBatchBlacklistRecord",
 			Handler:       _Blacklist_DeleteBatchBlacklistRecord_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "IsBlacklistedBatch",
+			Handler:       _Blacklist_IsBlacklistedBatch_Handler,
+			ServerStreams: true,
 			ClientStreams: true,
 		},
 	},